@@ -0,0 +1,9 @@
+// Package algo provides generic algorithms that build on top of the data
+// structures in the ds package.
+//
+// The graph algorithms in this package do not require an explicit graph
+// representation. Instead, they accept a neighbour function that returns the
+// adjacent nodes of a given node, together with the weights of the edges
+// leading to them. This allows them to be run against implicit graphs, like
+// grid maps, without having to materialize all edges in memory.
+package algo
@@ -0,0 +1,36 @@
+package algo_test
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/mokiat/gog/algo"
+)
+
+func ExampleDijkstra() {
+	roads := map[string]map[string]int{
+		"home":   {"park": 5, "market": 2},
+		"market": {"park": 1, "office": 7},
+		"park":   {"office": 3},
+	}
+	neighbours := func(place string) iter.Seq2[string, int] {
+		return func(yield func(string, int) bool) {
+			for _, next := range []string{"market", "office", "park"} {
+				if distance, ok := roads[place][next]; ok {
+					if !yield(next, distance) {
+						return
+					}
+				}
+			}
+		}
+	}
+
+	path, cost, err := algo.Dijkstra("home", "office", neighbours)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(path, cost)
+
+	// Output:
+	// [home market park office] 6
+}
@@ -0,0 +1,189 @@
+package algo
+
+import (
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/mokiat/gog/constr"
+	"github.com/mokiat/gog/ds"
+)
+
+var (
+	// ErrNoPath indicates that the goal node is not reachable from the start
+	// node.
+	ErrNoPath = errors.New("no path to goal")
+
+	// ErrNegativeCycle indicates that a cycle with negative total weight is
+	// reachable from the start node, which means that there is no shortest
+	// path.
+	ErrNegativeCycle = errors.New("negative weight cycle")
+)
+
+// NeighbourFunc returns the nodes that are adjacent to the specified node,
+// together with the weights of the edges leading to them.
+type NeighbourFunc[N comparable, W constr.Numeric] func(node N) iter.Seq2[N, W]
+
+// Dijkstra finds the shortest path from start to goal using Dijkstra's
+// algorithm. It returns the nodes of the path, including start and goal,
+// and the total cost of the path. ErrNoPath is returned if the goal is not
+// reachable.
+//
+// Edge weights must not be negative. Use BellmanFord for graphs that have
+// negative weights.
+func Dijkstra[N comparable, W constr.Numeric](start, goal N, neighbours NeighbourFunc[N, W]) ([]N, W, error) {
+	return AStar(start, goal, neighbours, func(N) W {
+		return 0
+	})
+}
+
+// AStar finds the shortest path from start to goal using the A* algorithm,
+// which is guided by the specified heuristic function. It returns the nodes
+// of the path, including start and goal, and the total cost of the path.
+// ErrNoPath is returned if the goal is not reachable.
+//
+// The heuristic should estimate the remaining cost from a node to the goal.
+// In order for the returned path to be the shortest one, the heuristic must
+// never overestimate that cost and must be consistent. Edge weights must not
+// be negative.
+func AStar[N comparable, W constr.Numeric](start, goal N, neighbours NeighbourFunc[N, W], heuristic func(node N) W) ([]N, W, error) {
+	costs := map[N]W{start: 0}
+	previous := make(map[N]N)
+	closed := ds.NewSet[N](0)
+
+	frontier := ds.NewHeap(0, func(a, b searchItem[N, W]) bool {
+		return a.priority < b.priority
+	})
+	frontier.Push(searchItem[N, W]{
+		node:     start,
+		cost:     0,
+		priority: heuristic(start),
+	})
+
+	for !frontier.IsEmpty() {
+		item := frontier.Pop()
+		if item.node == goal {
+			return buildPath(previous, start, goal), item.cost, nil
+		}
+		if !closed.Add(item.node) {
+			continue // stale entry for an already settled node
+		}
+		for neighbour, weight := range neighbours(item.node) {
+			if closed.Contains(neighbour) {
+				continue
+			}
+			cost := item.cost + weight
+			if knownCost, ok := costs[neighbour]; ok && knownCost <= cost {
+				continue
+			}
+			costs[neighbour] = cost
+			previous[neighbour] = item.node
+			frontier.Push(searchItem[N, W]{
+				node:     neighbour,
+				cost:     cost,
+				priority: cost + heuristic(neighbour),
+			})
+		}
+	}
+
+	var zero W
+	return nil, zero, ErrNoPath
+}
+
+// BellmanFord finds the shortest path from start to goal using the
+// Bellman-Ford algorithm. Unlike Dijkstra, it supports negative edge
+// weights. It returns the nodes of the path, including start and goal,
+// and the total cost of the path.
+//
+// ErrNoPath is returned if the goal is not reachable and ErrNegativeCycle
+// is returned if a negative weight cycle is reachable from start.
+//
+// The algorithm needs to know all edges upfront, so it first explores all
+// nodes that are reachable from start.
+func BellmanFord[N comparable, W constr.Numeric](start, goal N, neighbours NeighbourFunc[N, W]) ([]N, W, error) {
+	var zero W
+	edges, nodeCount := collectEdges(start, neighbours)
+
+	costs := map[N]W{start: 0}
+	previous := make(map[N]N)
+	relax := func() bool {
+		var changed bool
+		for _, edge := range edges {
+			fromCost, ok := costs[edge.from]
+			if !ok {
+				continue
+			}
+			cost := fromCost + edge.weight
+			if toCost, ok := costs[edge.to]; ok && toCost <= cost {
+				continue
+			}
+			costs[edge.to] = cost
+			previous[edge.to] = edge.from
+			changed = true
+		}
+		return changed
+	}
+
+	// Shortest paths have at most nodeCount-1 edges, so if relaxation is
+	// still possible after nodeCount iterations, there is a negative cycle.
+	converged := false
+	for range nodeCount {
+		if !relax() {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return nil, zero, ErrNegativeCycle
+	}
+
+	cost, ok := costs[goal]
+	if !ok {
+		return nil, zero, ErrNoPath
+	}
+	return buildPath(previous, start, goal), cost, nil
+}
+
+type searchItem[N comparable, W constr.Numeric] struct {
+	node     N
+	cost     W
+	priority W
+}
+
+type weightedEdge[N comparable, W constr.Numeric] struct {
+	from   N
+	to     N
+	weight W
+}
+
+func collectEdges[N comparable, W constr.Numeric](start N, neighbours NeighbourFunc[N, W]) ([]weightedEdge[N, W], int) {
+	var edges []weightedEdge[N, W]
+	visited := ds.NewSet[N](0)
+	queue := ds.NewQueue[N](0)
+	visited.Add(start)
+	queue.Push(start)
+	for !queue.IsEmpty() {
+		node := queue.Pop()
+		for neighbour, weight := range neighbours(node) {
+			edges = append(edges, weightedEdge[N, W]{
+				from:   node,
+				to:     neighbour,
+				weight: weight,
+			})
+			if visited.Add(neighbour) {
+				queue.Push(neighbour)
+			}
+		}
+	}
+	return edges, visited.Size()
+}
+
+func buildPath[N comparable](previous map[N]N, start, goal N) []N {
+	path := []N{goal}
+	for node := goal; node != start; {
+		node = previous[node]
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return path
+}
@@ -0,0 +1,155 @@
+package algo_test
+
+import (
+	"iter"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/algo"
+)
+
+var _ = Describe("Path", func() {
+	type edge struct {
+		to     string
+		weight int
+	}
+
+	neighboursOf := func(edges map[string][]edge) algo.NeighbourFunc[string, int] {
+		return func(node string) iter.Seq2[string, int] {
+			return func(yield func(string, int) bool) {
+				for _, e := range edges[node] {
+					if !yield(e.to, e.weight) {
+						return
+					}
+				}
+			}
+		}
+	}
+
+	// a --1--> b --2--> d
+	// a --4--> c --1--> d --1--> e
+	// a --10-> e
+	network := neighboursOf(map[string][]edge{
+		"a": {{"b", 1}, {"c", 4}, {"e", 10}},
+		"b": {{"d", 2}},
+		"c": {{"d", 1}},
+		"d": {{"e", 1}},
+	})
+
+	type point struct {
+		x, y int
+	}
+
+	// 0 = open, 1 = wall
+	grid := [][]int{
+		{0, 0, 0, 0},
+		{1, 1, 1, 0},
+		{0, 0, 0, 0},
+		{0, 1, 1, 1},
+		{0, 0, 0, 0},
+	}
+	gridNeighbours := func(p point) iter.Seq2[point, int] {
+		return func(yield func(point, int) bool) {
+			for _, d := range []point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+				n := point{p.x + d.x, p.y + d.y}
+				if n.y < 0 || n.y >= len(grid) || n.x < 0 || n.x >= len(grid[n.y]) {
+					continue
+				}
+				if grid[n.y][n.x] == 1 {
+					continue
+				}
+				if !yield(n, 1) {
+					return
+				}
+			}
+		}
+	}
+	manhattan := func(goal point) func(point) int {
+		return func(p point) int {
+			return max(goal.x-p.x, p.x-goal.x) + max(goal.y-p.y, p.y-goal.y)
+		}
+	}
+
+	Describe("Dijkstra", func() {
+		It("finds the shortest path", func() {
+			path, cost, err := algo.Dijkstra("a", "e", network)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal([]string{"a", "b", "d", "e"}))
+			Expect(cost).To(Equal(4))
+		})
+
+		It("returns a single node path when start is the goal", func() {
+			path, cost, err := algo.Dijkstra("a", "a", network)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal([]string{"a"}))
+			Expect(cost).To(BeZero())
+		})
+
+		It("reports unreachable goals", func() {
+			path, _, err := algo.Dijkstra("e", "a", network)
+			Expect(err).To(MatchError(algo.ErrNoPath))
+			Expect(path).To(BeNil())
+		})
+
+		It("works on implicit grid graphs", func() {
+			_, cost, err := algo.Dijkstra(point{0, 0}, point{0, 4}, gridNeighbours)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cost).To(Equal(10))
+		})
+	})
+
+	Describe("AStar", func() {
+		It("finds the shortest path on a grid", func() {
+			start, goal := point{0, 0}, point{0, 4}
+			path, cost, err := algo.AStar(start, goal, gridNeighbours, manhattan(goal))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cost).To(Equal(10))
+			Expect(path).To(HaveLen(11))
+			Expect(path[0]).To(Equal(start))
+			Expect(path[10]).To(Equal(goal))
+		})
+
+		It("reports unreachable goals", func() {
+			start, goal := point{0, 0}, point{1, 1}
+			_, _, err := algo.AStar(start, goal, gridNeighbours, manhattan(goal))
+			Expect(err).To(MatchError(algo.ErrNoPath))
+		})
+	})
+
+	Describe("BellmanFord", func() {
+		It("finds the shortest path", func() {
+			path, cost, err := algo.BellmanFord("a", "e", network)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal([]string{"a", "b", "d", "e"}))
+			Expect(cost).To(Equal(4))
+		})
+
+		It("supports negative weights", func() {
+			negative := neighboursOf(map[string][]edge{
+				"a": {{"b", 4}, {"c", 2}},
+				"b": {{"d", 1}},
+				"c": {{"b", -3}},
+			})
+			path, cost, err := algo.BellmanFord("a", "d", negative)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal([]string{"a", "c", "b", "d"}))
+			Expect(cost).To(Equal(0))
+		})
+
+		It("reports unreachable goals", func() {
+			_, _, err := algo.BellmanFord("e", "a", network)
+			Expect(err).To(MatchError(algo.ErrNoPath))
+		})
+
+		It("reports negative cycles", func() {
+			cyclic := neighboursOf(map[string][]edge{
+				"a": {{"b", 1}},
+				"b": {{"c", -2}},
+				"c": {{"b", 1}, {"d", 1}},
+			})
+			_, _, err := algo.BellmanFord("a", "d", cyclic)
+			Expect(err).To(MatchError(algo.ErrNegativeCycle))
+		})
+	})
+})
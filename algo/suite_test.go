@@ -0,0 +1,13 @@
+package algo_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAlgo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Algorithms Suite")
+}
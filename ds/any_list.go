@@ -0,0 +1,235 @@
+package ds
+
+import (
+	"iter"
+	"slices"
+)
+
+// NewAnyList creates a new AnyList with the given capacity. The capacity can
+// be used as a form of optimization. Regardless of the value, the initial
+// size of the list is zero and the list can grow past the specified capacity.
+func NewAnyList[T any](initialCapacity int) *AnyList[T] {
+	return &AnyList[T]{
+		items: make([]T, 0, initialCapacity),
+	}
+}
+
+// AnyListFromSlice constructs a new AnyList that is based on the items from
+// the specified slice.
+//
+// It is safe to modify the slice afterwards, as the list creates its own
+// internal copy.
+func AnyListFromSlice[T any](items []T) *AnyList[T] {
+	return &AnyList[T]{
+		items: slices.Clone(items),
+	}
+}
+
+// AnyListFromSeq constructs a new AnyList that contains the items of the
+// specified sequence.
+func AnyListFromSeq[T any](src iter.Seq[T]) *AnyList[T] {
+	return &AnyList[T]{
+		items: slices.Collect(src),
+	}
+}
+
+// AnyList represents a sequence of items of any type.
+//
+// Unlike List, an AnyList does not require its items to be comparable.
+// Operations that depend on equality are instead available as variants
+// that accept a predicate or comparison function.
+type AnyList[T any] struct {
+	items []T
+}
+
+// Size returns the number of items contained in this list.
+func (l *AnyList[T]) Size() int {
+	return len(l.items)
+}
+
+// IsEmpty returns whether this list has no elements.
+func (l *AnyList[T]) IsEmpty() bool {
+	return len(l.items) == 0
+}
+
+// Add appends the specified item to the list.
+func (l *AnyList[T]) Add(item T) {
+	l.items = append(l.items, item)
+}
+
+// AddAll appends the specified items to the list.
+func (l *AnyList[T]) AddAll(items ...T) {
+	l.items = append(l.items, items...)
+}
+
+// Insert inserts the specified item at the specified index, shifting the
+// item at that index and all following items to the right.
+//
+// This method will panic if the index is outside the range [0, Size()].
+func (l *AnyList[T]) Insert(index int, item T) {
+	l.items = slices.Insert(l.items, index, item)
+}
+
+// RemoveAt removes the item at the specified index and returns it, shifting
+// all following items to the left.
+//
+// This method will panic if the index is outside the list bounds.
+func (l *AnyList[T]) RemoveAt(index int) T {
+	item := l.items[index]
+	l.items = slices.Delete(l.items, index, index+1)
+	return item
+}
+
+// RemoveFunc removes the first item for which the specified function returns
+// true. This method returns true if such an item was found and false
+// otherwise.
+func (l *AnyList[T]) RemoveFunc(fn func(item T) bool) bool {
+	index := l.IndexFunc(fn)
+	if index < 0 {
+		return false
+	}
+	l.items = slices.Delete(l.items, index, index+1)
+	return true
+}
+
+// Get returns the item in this list that is located at the specified index
+// (starting from zero).
+//
+// This method will panic if the index is outside the list bounds.
+func (l *AnyList[T]) Get(index int) T {
+	return l.items[index]
+}
+
+// Set modifies the item at the specified index.
+//
+// This method will panic if the index is outside the list bounds.
+func (l *AnyList[T]) Set(index int, value T) {
+	l.items[index] = value
+}
+
+// Swap exchanges the items at the two specified indices.
+//
+// This method will panic if either index is outside the list bounds.
+func (l *AnyList[T]) Swap(i, j int) {
+	l.items[i], l.items[j] = l.items[j], l.items[i]
+}
+
+// Reverse reverses the order of the items in this list.
+func (l *AnyList[T]) Reverse() {
+	slices.Reverse(l.items)
+}
+
+// SortFunc sorts the items in this list in ascending order as determined by
+// the specified compare function. The sort is stable.
+func (l *AnyList[T]) SortFunc(compare func(a, b T) int) {
+	slices.SortStableFunc(l.items, compare)
+}
+
+// SubList returns a new list that contains a copy of the items in the range
+// [from, to).
+//
+// This method will panic if the range is outside the list bounds.
+func (l *AnyList[T]) SubList(from, to int) *AnyList[T] {
+	return &AnyList[T]{
+		items: slices.Clone(l.items[from:to]),
+	}
+}
+
+// IndexFunc returns the index of the first item for which the specified
+// function returns true. If there is no such item, this method returns -1.
+func (l *AnyList[T]) IndexFunc(fn func(item T) bool) int {
+	return slices.IndexFunc(l.items, fn)
+}
+
+// ContainsFunc returns whether this list has at least one item for which
+// the specified function returns true.
+func (l *AnyList[T]) ContainsFunc(fn func(item T) bool) bool {
+	return l.IndexFunc(fn) >= 0
+}
+
+// EqualsFunc returns whether this list matches the provided list, using the
+// specified function to compare items.
+func (l *AnyList[T]) EqualsFunc(other *AnyList[T], eq func(a, b T) bool) bool {
+	return slices.EqualFunc(l.items, other.items, eq)
+}
+
+// Unbox provides direct access to the inner representation of the list.
+// The returned slice should not be modified, otherwise there is a risk that
+// the list might not work correctly afterwards. Even if it works now, a future
+// version might break that behavior.
+//
+// This method should only be used when performance is critical and memory
+// allocation is not desired.
+func (l *AnyList[T]) Unbox() []T {
+	return l.items
+}
+
+// Items returns all items stored in this list as a slice. It is safe to mutate
+// the returned slice as it is a copy of the inner representation.
+//
+// If performance is needed, consider using Unbox method instead.
+func (l *AnyList[T]) Items() []T {
+	return slices.Clone(l.items)
+}
+
+// All returns a sequence over all items in this list, in order.
+func (l *AnyList[T]) All() iter.Seq[T] {
+	return slices.Values(l.items)
+}
+
+// Indexed returns a sequence over all items in this list, in order, paired
+// with their indices.
+func (l *AnyList[T]) Indexed() iter.Seq2[int, T] {
+	return slices.All(l.items)
+}
+
+// Each is a helper method allows one to iterate over all items in this list
+// through a closure function.
+func (l *AnyList[T]) Each(iterator func(item T)) {
+	for _, item := range l.items {
+		iterator(item)
+	}
+}
+
+// Clear removes all items from this list.
+func (l *AnyList[T]) Clear() {
+	clear(l.items)
+	l.items = l.items[:0]
+}
+
+// Clip removes unused capacity from the list.
+func (l *AnyList[T]) Clip() {
+	l.items = slices.Clip(l.items)
+}
+
+// MarshalJSON encodes this list as a JSON array.
+func (l *AnyList[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(l.items)
+}
+
+// UnmarshalJSON decodes a JSON array into this list, replacing any existing
+// items.
+func (l *AnyList[T]) UnmarshalJSON(data []byte) error {
+	items, err := unmarshalItemsJSON[T](data)
+	if err != nil {
+		return err
+	}
+	l.items = items
+	return nil
+}
+
+// MarshalBinary encodes this list using encoding/gob.
+func (l *AnyList[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(l.items)
+}
+
+// UnmarshalBinary decodes a list that was encoded with MarshalBinary,
+// replacing any existing items.
+func (l *AnyList[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalItemsBinary[T](data)
+	if err != nil {
+		return err
+	}
+	l.items = items
+	return nil
+}
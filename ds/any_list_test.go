@@ -0,0 +1,181 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("AnyList", func() {
+	type record struct {
+		Name string
+		Tags []string
+	}
+
+	var list *ds.AnyList[record]
+
+	names := func() []string {
+		var result []string
+		list.Each(func(item record) {
+			result = append(result, item.Name)
+		})
+		return result
+	}
+
+	byName := func(name string) func(record) bool {
+		return func(item record) bool {
+			return item.Name == name
+		}
+	}
+
+	BeforeEach(func() {
+		list = ds.NewAnyList[record](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(list.IsEmpty()).To(BeTrue())
+		Expect(list.Size()).To(BeZero())
+		Expect(list.Items()).To(BeEmpty())
+	})
+
+	It("preallocates the initial capacity", func() {
+		list = ds.NewAnyList[record](16)
+		Expect(cap(list.Unbox())).To(Equal(16))
+	})
+
+	When("items are added", func() {
+		BeforeEach(func() {
+			list.Add(record{Name: "b", Tags: []string{"x"}})
+			list.AddAll(
+				record{Name: "c"},
+				record{Name: "a", Tags: []string{"y", "z"}},
+			)
+		})
+
+		It("has the correct size", func() {
+			Expect(list.IsEmpty()).To(BeFalse())
+			Expect(list.Size()).To(Equal(3))
+		})
+
+		It("keeps the items in order", func() {
+			Expect(names()).To(Equal([]string{"b", "c", "a"}))
+			Expect(list.Get(2).Tags).To(Equal([]string{"y", "z"}))
+		})
+
+		It("is possible to iterate with a sequence", func() {
+			var result []string
+			for item := range list.All() {
+				result = append(result, item.Name)
+			}
+			Expect(result).To(Equal([]string{"b", "c", "a"}))
+			for index, item := range list.Indexed() {
+				Expect(item.Name).To(Equal(result[index]))
+			}
+		})
+
+		It("is possible to construct from a sequence", func() {
+			other := ds.AnyListFromSeq(list.All())
+			Expect(other.Size()).To(Equal(3))
+			Expect(other.Get(0).Name).To(Equal("b"))
+		})
+
+		It("is possible to insert at an index", func() {
+			list.Insert(0, record{Name: "first"})
+			list.Insert(2, record{Name: "middle"})
+			list.Insert(list.Size(), record{Name: "last"})
+			Expect(names()).To(Equal([]string{"first", "b", "middle", "c", "a", "last"}))
+		})
+
+		It("panics when inserting out of bounds", func() {
+			Expect(func() { list.Insert(4, record{}) }).To(Panic())
+		})
+
+		It("is possible to remove at an index", func() {
+			removed := list.RemoveAt(1)
+			Expect(removed.Name).To(Equal("c"))
+			Expect(names()).To(Equal([]string{"b", "a"}))
+		})
+
+		It("is possible to remove with a predicate", func() {
+			Expect(list.RemoveFunc(byName("a"))).To(BeTrue())
+			Expect(list.RemoveFunc(byName("a"))).To(BeFalse())
+			Expect(names()).To(Equal([]string{"b", "c"}))
+		})
+
+		It("is possible to set an item", func() {
+			list.Set(1, record{Name: "d"})
+			Expect(names()).To(Equal([]string{"b", "d", "a"}))
+		})
+
+		It("is possible to swap items", func() {
+			list.Swap(0, 2)
+			Expect(names()).To(Equal([]string{"a", "c", "b"}))
+		})
+
+		It("is possible to reverse the items", func() {
+			list.Reverse()
+			Expect(names()).To(Equal([]string{"a", "c", "b"}))
+		})
+
+		It("is possible to sort the items", func() {
+			list.SortFunc(func(a, b record) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+			Expect(names()).To(Equal([]string{"a", "b", "c"}))
+		})
+
+		It("is possible to get a sub list", func() {
+			subList := list.SubList(1, 3)
+			Expect(subList.Size()).To(Equal(2))
+			subList.Set(0, record{Name: "changed"})
+			Expect(names()).To(Equal([]string{"b", "c", "a"}))
+		})
+
+		It("is possible to find items with a predicate", func() {
+			Expect(list.IndexFunc(byName("c"))).To(Equal(1))
+			Expect(list.IndexFunc(byName("missing"))).To(Equal(-1))
+			Expect(list.ContainsFunc(byName("a"))).To(BeTrue())
+			Expect(list.ContainsFunc(byName("missing"))).To(BeFalse())
+		})
+
+		It("is possible to compare with a function", func() {
+			eq := func(a, b record) bool {
+				return a.Name == b.Name && slices.Equal(a.Tags, b.Tags)
+			}
+			other := ds.AnyListFromSlice(list.Items())
+			Expect(list.EqualsFunc(other, eq)).To(BeTrue())
+			other.Reverse()
+			Expect(list.EqualsFunc(other, eq)).To(BeFalse())
+		})
+
+		When("the list is cleared", func() {
+			BeforeEach(func() {
+				list.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(list.IsEmpty()).To(BeTrue())
+				Expect(list.Items()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips an AnyList with structured items", func() {
+			type point struct {
+				X, Y []int
+			}
+			list := ds.AnyListFromSlice([]point{{X: []int{1}, Y: []int{2, 3}}})
+			data, err := json.Marshal(list)
+			Expect(err).ToNot(HaveOccurred())
+			var restored ds.AnyList[point]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Items()).To(Equal(list.Items()))
+		})
+	})
+})
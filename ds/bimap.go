@@ -0,0 +1,241 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrValueConflict indicates that a value is already associated with a
+// different key in a BiMap.
+var ErrValueConflict = errors.New("value already mapped to another key")
+
+// ConflictPolicy specifies how a BiMap handles the insertion of a value that
+// is already associated with a different key.
+type ConflictPolicy int
+
+const (
+	// ConflictReject causes Put to fail with ErrValueConflict.
+	ConflictReject ConflictPolicy = iota
+
+	// ConflictOverwrite causes Put to remove the existing entry that holds
+	// the value, before adding the new one.
+	ConflictOverwrite
+)
+
+// NewBiMap creates a new BiMap instance with the specified initial capacity,
+// which is only used to preallocate memory and does not act as an upper
+// bound. The policy controls how value conflicts are handled by Put.
+func NewBiMap[K comparable, V comparable](initialCapacity int, policy ConflictPolicy) *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward:  make(map[K]V, initialCapacity),
+		backward: make(map[V]K, initialCapacity),
+		policy:   policy,
+	}
+}
+
+// BiMapFromMap creates a new BiMap instance based on the entries of the
+// provided map. The resulting BiMap uses the ConflictReject policy.
+//
+// An error is returned if two keys of the map have the same value.
+func BiMapFromMap[K comparable, V comparable](m map[K]V) (*BiMap[K, V], error) {
+	result := NewBiMap[K, V](len(m), ConflictReject)
+	for key, value := range m {
+		if err := result.Put(key, value); err != nil {
+			return nil, fmt.Errorf("error adding key %v: %w", key, err)
+		}
+	}
+	return result, nil
+}
+
+// BiMap represents a one-to-one mapping between keys and values, which
+// allows lookups in both directions in O(1) time.
+type BiMap[K comparable, V comparable] struct {
+	forward  map[K]V
+	backward map[V]K
+	policy   ConflictPolicy
+	inverse  *BiMap[V, K]
+}
+
+// IsEmpty returns true if there are no entries in this BiMap.
+func (m *BiMap[K, V]) IsEmpty() bool {
+	return len(m.forward) == 0
+}
+
+// Size returns the number of entries stored in this BiMap.
+func (m *BiMap[K, V]) Size() int {
+	return len(m.forward)
+}
+
+// Put associates the specified key with the specified value. Any previous
+// value of the key is replaced.
+//
+// If the value is already associated with a different key, the outcome
+// depends on the ConflictPolicy of this BiMap. With ConflictReject, the
+// BiMap is not modified and ErrValueConflict is returned. With
+// ConflictOverwrite, the other entry is removed.
+func (m *BiMap[K, V]) Put(key K, value V) error {
+	if existingKey, ok := m.backward[value]; ok && existingKey != key {
+		if m.policy == ConflictReject {
+			return ErrValueConflict
+		}
+		delete(m.forward, existingKey)
+	}
+	if existingValue, ok := m.forward[key]; ok {
+		delete(m.backward, existingValue)
+	}
+	m.forward[key] = value
+	m.backward[value] = key
+	return nil
+}
+
+// GetByKey returns the value that is associated with the specified key. The
+// second return value is false if there is no such key.
+func (m *BiMap[K, V]) GetByKey(key K) (V, bool) {
+	value, ok := m.forward[key]
+	return value, ok
+}
+
+// GetByValue returns the key that is associated with the specified value.
+// The second return value is false if there is no such value.
+func (m *BiMap[K, V]) GetByValue(value V) (K, bool) {
+	key, ok := m.backward[value]
+	return key, ok
+}
+
+// ContainsKey returns whether this BiMap contains the specified key.
+func (m *BiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.forward[key]
+	return ok
+}
+
+// ContainsValue returns whether this BiMap contains the specified value.
+func (m *BiMap[K, V]) ContainsValue(value V) bool {
+	_, ok := m.backward[value]
+	return ok
+}
+
+// RemoveByKey removes the entry with the specified key. This method returns
+// true if there was such an entry and false otherwise.
+func (m *BiMap[K, V]) RemoveByKey(key K) bool {
+	value, ok := m.forward[key]
+	if !ok {
+		return false
+	}
+	delete(m.forward, key)
+	delete(m.backward, value)
+	return true
+}
+
+// RemoveByValue removes the entry with the specified value. This method
+// returns true if there was such an entry and false otherwise.
+func (m *BiMap[K, V]) RemoveByValue(value V) bool {
+	key, ok := m.backward[value]
+	if !ok {
+		return false
+	}
+	delete(m.forward, key)
+	delete(m.backward, value)
+	return true
+}
+
+// Inverse returns a view of this BiMap where keys and values are swapped.
+// The view shares its storage with this BiMap, so changes to one of them
+// are visible through the other. The view uses the same ConflictPolicy.
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	if m.inverse == nil {
+		m.inverse = &BiMap[V, K]{
+			forward:  m.backward,
+			backward: m.forward,
+			policy:   m.policy,
+			inverse:  m,
+		}
+	}
+	return m.inverse
+}
+
+// All returns a sequence over all entries of this BiMap.
+//
+// Note: The entries are returned in a random order.
+func (m *BiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range m.forward {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all entries from this BiMap.
+func (m *BiMap[K, V]) Clear() {
+	clear(m.forward)
+	clear(m.backward)
+}
+
+// MarshalJSON encodes this BiMap as a JSON array of objects, each holding a
+// key and its value. If the keys are of an integer, float or string type,
+// the objects are sorted by key so that the output is deterministic.
+func (m *BiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(m.entries())
+}
+
+// UnmarshalJSON decodes a JSON array that was produced by MarshalJSON into
+// this BiMap. Existing entries are removed beforehand. Value conflicts are
+// handled according to the ConflictPolicy of this BiMap.
+func (m *BiMap[K, V]) UnmarshalJSON(data []byte) error {
+	entries, err := unmarshalItemsJSON[keyValue[K, V]](data)
+	if err != nil {
+		return err
+	}
+	return m.replace(entries)
+}
+
+// MarshalBinary encodes this BiMap using encoding/gob. If the keys are of
+// an integer, float or string type, they are sorted in ascending order so
+// that the output is deterministic.
+func (m *BiMap[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(m.entries())
+}
+
+// UnmarshalBinary decodes a BiMap that was encoded with MarshalBinary.
+// Existing entries are removed beforehand.
+func (m *BiMap[K, V]) UnmarshalBinary(data []byte) error {
+	entries, err := unmarshalItemsBinary[keyValue[K, V]](data)
+	if err != nil {
+		return err
+	}
+	return m.replace(entries)
+}
+
+func (m *BiMap[K, V]) entries() []keyValue[K, V] {
+	result := make([]keyValue[K, V], 0, len(m.forward))
+	for key, value := range m.forward {
+		result = append(result, keyValue[K, V]{
+			Key:   key,
+			Value: value,
+		})
+	}
+	sortByOrderedKey(result, func(entry keyValue[K, V]) K {
+		return entry.Key
+	})
+	return result
+}
+
+func (m *BiMap[K, V]) replace(entries []keyValue[K, V]) error {
+	if m.forward == nil {
+		m.forward = make(map[K]V, len(entries))
+		m.backward = make(map[V]K, len(entries))
+		if m.inverse != nil {
+			m.inverse.forward = m.backward
+			m.inverse.backward = m.forward
+		}
+	}
+	m.Clear()
+	for _, entry := range entries {
+		if err := m.Put(entry.Key, entry.Value); err != nil {
+			return fmt.Errorf("error adding key %v: %w", entry.Key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"maps"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("BiMap", func() {
+	var biMap *ds.BiMap[int, string]
+
+	BeforeEach(func() {
+		biMap = ds.NewBiMap[int, string](0, ds.ConflictReject)
+	})
+
+	It("is empty by default", func() {
+		Expect(biMap.IsEmpty()).To(BeTrue())
+		Expect(biMap.Size()).To(BeZero())
+	})
+
+	When("entries are added", func() {
+		BeforeEach(func() {
+			Expect(biMap.Put(1, "one")).To(Succeed())
+			Expect(biMap.Put(2, "two")).To(Succeed())
+			Expect(biMap.Put(3, "three")).To(Succeed())
+		})
+
+		It("has the correct size", func() {
+			Expect(biMap.IsEmpty()).To(BeFalse())
+			Expect(biMap.Size()).To(Equal(3))
+		})
+
+		It("is possible to look up by key", func() {
+			value, ok := biMap.GetByKey(2)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("two"))
+			_, ok = biMap.GetByKey(4)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("is possible to look up by value", func() {
+			key, ok := biMap.GetByValue("three")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal(3))
+			_, ok = biMap.GetByValue("four")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("replaces the value of an existing key", func() {
+			Expect(biMap.Put(1, "uno")).To(Succeed())
+			Expect(biMap.ContainsValue("one")).To(BeFalse())
+			key, ok := biMap.GetByValue("uno")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal(1))
+			Expect(biMap.Size()).To(Equal(3))
+		})
+
+		It("accepts putting the same entry again", func() {
+			Expect(biMap.Put(1, "one")).To(Succeed())
+			Expect(biMap.Size()).To(Equal(3))
+		})
+
+		It("rejects value conflicts", func() {
+			Expect(biMap.Put(4, "one")).To(MatchError(ds.ErrValueConflict))
+			Expect(biMap.ContainsKey(4)).To(BeFalse())
+			key, ok := biMap.GetByValue("one")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal(1))
+		})
+
+		It("is possible to remove by key", func() {
+			Expect(biMap.RemoveByKey(1)).To(BeTrue())
+			Expect(biMap.RemoveByKey(1)).To(BeFalse())
+			Expect(biMap.ContainsValue("one")).To(BeFalse())
+		})
+
+		It("is possible to remove by value", func() {
+			Expect(biMap.RemoveByValue("two")).To(BeTrue())
+			Expect(biMap.RemoveByValue("two")).To(BeFalse())
+			Expect(biMap.ContainsKey(2)).To(BeFalse())
+		})
+
+		It("iterates all entries", func() {
+			Expect(maps.Collect(biMap.All())).To(Equal(map[int]string{
+				1: "one", 2: "two", 3: "three",
+			}))
+		})
+
+		Describe("inverse", func() {
+			var inverse *ds.BiMap[string, int]
+
+			BeforeEach(func() {
+				inverse = biMap.Inverse()
+			})
+
+			It("swaps keys and values", func() {
+				value, ok := inverse.GetByKey("two")
+				Expect(ok).To(BeTrue())
+				Expect(value).To(Equal(2))
+				key, ok := inverse.GetByValue(3)
+				Expect(ok).To(BeTrue())
+				Expect(key).To(Equal("three"))
+			})
+
+			It("shares storage with the original", func() {
+				Expect(inverse.Put("four", 4)).To(Succeed())
+				value, ok := biMap.GetByKey(4)
+				Expect(ok).To(BeTrue())
+				Expect(value).To(Equal("four"))
+				biMap.RemoveByKey(1)
+				Expect(inverse.ContainsKey("one")).To(BeFalse())
+			})
+
+			It("returns the original as its inverse", func() {
+				Expect(inverse.Inverse()).To(BeIdenticalTo(biMap))
+			})
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				biMap.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(biMap.IsEmpty()).To(BeTrue())
+				Expect(biMap.Inverse().IsEmpty()).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("overwrite policy", func() {
+		BeforeEach(func() {
+			biMap = ds.NewBiMap[int, string](0, ds.ConflictOverwrite)
+			Expect(biMap.Put(1, "one")).To(Succeed())
+		})
+
+		It("replaces the entry that holds the value", func() {
+			Expect(biMap.Put(2, "one")).To(Succeed())
+			Expect(biMap.ContainsKey(1)).To(BeFalse())
+			key, ok := biMap.GetByValue("one")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal(2))
+			Expect(biMap.Size()).To(Equal(1))
+		})
+	})
+
+	Describe("BiMapFromMap", func() {
+		It("creates a BiMap from a map", func() {
+			result, err := ds.BiMapFromMap(map[string]int{"a": 1, "b": 2})
+			Expect(err).ToNot(HaveOccurred())
+			key, ok := result.GetByValue(2)
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("b"))
+		})
+
+		It("rejects duplicate values", func() {
+			_, err := ds.BiMapFromMap(map[string]int{"a": 1, "b": 1})
+			Expect(err).To(MatchError(ds.ErrValueConflict))
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("marshals to a sorted JSON array of entries", func() {
+			biMap, err := ds.BiMapFromMap(map[string]int{"b": 2, "a": 1})
+			Expect(err).ToNot(HaveOccurred())
+			data, err := json.Marshal(biMap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[{"key":"a","value":1},{"key":"b","value":2}]`))
+		})
+
+		It("unmarshals into a zero value", func() {
+			var biMap ds.BiMap[string, int]
+			Expect(json.Unmarshal([]byte(`[{"key":"a","value":1}]`), &biMap)).To(Succeed())
+			key, ok := biMap.GetByValue(1)
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("a"))
+		})
+
+		It("reports value conflicts", func() {
+			biMap := ds.NewBiMap[string, int](0, ds.ConflictReject)
+			err := json.Unmarshal([]byte(`[{"key":"a","value":1},{"key":"b","value":1}]`), biMap)
+			Expect(err).To(MatchError(ds.ErrValueConflict))
+		})
+
+		It("round-trips through gob", func() {
+			source, err := ds.BiMapFromMap(map[int]string{1: "x", 2: "y"})
+			Expect(err).ToNot(HaveOccurred())
+			var restored ds.BiMap[int, string]
+			gobRoundTrip(source, &restored)
+			value, ok := restored.GetByKey(2)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("y"))
+		})
+	})
+})
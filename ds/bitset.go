@@ -0,0 +1,321 @@
+package ds
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const bitsPerWord = 64
+
+// NewBitSet creates a new BitSet instance with the specified initial
+// capacity in bits, which is only used to preallocate memory and does not
+// act as an upper bound.
+func NewBitSet(initialCapacity int) *BitSet {
+	return &BitSet{
+		words: make([]uint64, 0, (initialCapacity+bitsPerWord-1)/bitsPerWord),
+	}
+}
+
+// BitSetFromSlice creates a new BitSet instance that has the bits at the
+// specified indices set.
+func BitSetFromSlice(indices []int) *BitSet {
+	result := NewBitSet(0)
+	for _, index := range indices {
+		result.Set(index)
+	}
+	return result
+}
+
+// BitSetUnion creates a new BitSet that is the union of the specified sets.
+func BitSetUnion(first, second *BitSet) *BitSet {
+	result := first.Clone()
+	result.Union(second)
+	return result
+}
+
+// BitSetIntersection creates a new BitSet that holds the intersection of
+// the specified sets.
+func BitSetIntersection(first, second *BitSet) *BitSet {
+	result := first.Clone()
+	result.Intersection(second)
+	return result
+}
+
+// BitSetDifference creates a new BitSet that holds the difference between
+// the first and the second specified sets.
+func BitSetDifference(first, second *BitSet) *BitSet {
+	result := first.Clone()
+	result.Difference(second)
+	return result
+}
+
+// BitSetSymmetricDifference creates a new BitSet that holds the bits that
+// are set in exactly one of the specified sets.
+func BitSetSymmetricDifference(first, second *BitSet) *BitSet {
+	result := first.Clone()
+	result.SymmetricDifference(second)
+	return result
+}
+
+// BitSet represents a set of non-negative integers, where each integer is
+// stored as a single bit. It is much more compact than Set[int] for dense
+// sets of small integers.
+//
+// Methods panic if a negative index is specified.
+type BitSet struct {
+	words []uint64
+}
+
+// IsEmpty returns whether this BitSet has no bits set.
+func (s *BitSet) IsEmpty() bool {
+	for _, word := range s.words {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of bits that are set in this BitSet.
+func (s *BitSet) Count() int {
+	var result int
+	for _, word := range s.words {
+		result += bits.OnesCount64(word)
+	}
+	return result
+}
+
+// Set sets the bit at the specified index.
+func (s *BitSet) Set(index int) {
+	word, mask := bitPosition(index)
+	s.grow(word + 1)
+	s.words[word] |= mask
+}
+
+// Clear clears the bit at the specified index.
+func (s *BitSet) Clear(index int) {
+	word, mask := bitPosition(index)
+	if word < len(s.words) {
+		s.words[word] &^= mask
+	}
+}
+
+// Flip toggles the bit at the specified index.
+func (s *BitSet) Flip(index int) {
+	word, mask := bitPosition(index)
+	s.grow(word + 1)
+	s.words[word] ^= mask
+}
+
+// Test returns whether the bit at the specified index is set.
+func (s *BitSet) Test(index int) bool {
+	word, mask := bitPosition(index)
+	return word < len(s.words) && s.words[word]&mask != 0
+}
+
+// NextSet returns the index of the first bit that is set, starting from the
+// specified index (inclusive). The second return value is false if there are
+// no more set bits.
+func (s *BitSet) NextSet(from int) (int, bool) {
+	word, _ := bitPosition(from)
+	if word >= len(s.words) {
+		return 0, false
+	}
+	current := s.words[word] >> (uint(from) % bitsPerWord)
+	if current != 0 {
+		return from + bits.TrailingZeros64(current), true
+	}
+	for word++; word < len(s.words); word++ {
+		if s.words[word] != 0 {
+			return word*bitsPerWord + bits.TrailingZeros64(s.words[word]), true
+		}
+	}
+	return 0, false
+}
+
+// All returns a sequence over the indices of all set bits in ascending
+// order.
+func (s *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for wordIndex, word := range s.words {
+			for word != 0 {
+				bit := bits.TrailingZeros64(word)
+				if !yield(wordIndex*bitsPerWord + bit) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// Items returns the indices of all set bits in ascending order.
+func (s *BitSet) Items() []int {
+	return slices.AppendSeq(make([]int, 0, s.Count()), s.All())
+}
+
+// Union sets all bits in this BitSet that are set in the other BitSet.
+func (s *BitSet) Union(other *BitSet) {
+	s.grow(len(other.words))
+	for i, word := range other.words {
+		s.words[i] |= word
+	}
+}
+
+// Intersection clears all bits in this BitSet that are not set in the other
+// BitSet.
+func (s *BitSet) Intersection(other *BitSet) {
+	for i := range s.words {
+		if i < len(other.words) {
+			s.words[i] &= other.words[i]
+		} else {
+			s.words[i] = 0
+		}
+	}
+}
+
+// Difference clears all bits in this BitSet that are set in the other
+// BitSet.
+func (s *BitSet) Difference(other *BitSet) {
+	for i := range min(len(s.words), len(other.words)) {
+		s.words[i] &^= other.words[i]
+	}
+}
+
+// SymmetricDifference toggles all bits in this BitSet that are set in the
+// other BitSet.
+func (s *BitSet) SymmetricDifference(other *BitSet) {
+	s.grow(len(other.words))
+	for i, word := range other.words {
+		s.words[i] ^= word
+	}
+}
+
+// Clone returns a copy of this BitSet.
+func (s *BitSet) Clone() *BitSet {
+	return &BitSet{
+		words: slices.Clone(s.words),
+	}
+}
+
+// Equals returns whether this BitSet has the same bits set as the provided
+// BitSet.
+func (s *BitSet) Equals(other *BitSet) bool {
+	return slices.Equal(s.trimmed(), other.trimmed())
+}
+
+// ClearAll clears all bits in this BitSet.
+func (s *BitSet) ClearAll() {
+	s.words = s.words[:0]
+}
+
+// Clip removes unused capacity from the BitSet.
+func (s *BitSet) Clip() {
+	s.words = slices.Clip(s.trimmed())
+}
+
+// MarshalBinary encodes this BitSet as a sequence of little-endian 64-bit
+// words.
+func (s *BitSet) MarshalBinary() ([]byte, error) {
+	words := s.trimmed()
+	result := make([]byte, 0, len(words)*8)
+	for _, word := range words {
+		result = binary.LittleEndian.AppendUint64(result, word)
+	}
+	return result, nil
+}
+
+// UnmarshalBinary decodes a BitSet that was encoded with MarshalBinary.
+func (s *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return errors.New("bitset data length is not a multiple of 8")
+	}
+	s.words = make([]uint64, len(data)/8)
+	for i := range s.words {
+		s.words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	return nil
+}
+
+// MarshalJSON encodes this BitSet as a JSON array of the indices of all set
+// bits in ascending order.
+func (s *BitSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Items())
+}
+
+// UnmarshalJSON decodes a BitSet from a JSON array of bit indices.
+func (s *BitSet) UnmarshalJSON(data []byte) error {
+	var indices []int
+	if err := json.Unmarshal(data, &indices); err != nil {
+		return err
+	}
+	s.words = s.words[:0]
+	for _, index := range indices {
+		if index < 0 {
+			return errors.New("bitset index must not be negative")
+		}
+		s.Set(index)
+	}
+	return nil
+}
+
+// MarshalText encodes this BitSet as a comma-separated list of the indices
+// of all set bits in ascending order, for example "1,3,5".
+func (s *BitSet) MarshalText() ([]byte, error) {
+	var result []byte
+	for index := range s.All() {
+		if len(result) > 0 {
+			result = append(result, ',')
+		}
+		result = strconv.AppendInt(result, int64(index), 10)
+	}
+	return result, nil
+}
+
+// UnmarshalText decodes a BitSet that was encoded with MarshalText.
+func (s *BitSet) UnmarshalText(text []byte) error {
+	s.words = s.words[:0]
+	if len(text) == 0 {
+		return nil
+	}
+	for field := range strings.SplitSeq(string(text), ",") {
+		index, err := strconv.Atoi(field)
+		if err != nil {
+			return fmt.Errorf("error parsing bitset index: %w", err)
+		}
+		if index < 0 {
+			return errors.New("bitset index must not be negative")
+		}
+		s.Set(index)
+	}
+	return nil
+}
+
+func (s *BitSet) grow(wordCount int) {
+	if count := len(s.words); wordCount > count {
+		s.words = slices.Grow(s.words, wordCount-count)[:wordCount]
+		clear(s.words[count:]) // capacity may hold stale words
+	}
+}
+
+func (s *BitSet) trimmed() []uint64 {
+	end := len(s.words)
+	for end > 0 && s.words[end-1] == 0 {
+		end--
+	}
+	return s.words[:end]
+}
+
+func bitPosition(index int) (int, uint64) {
+	if index < 0 {
+		panic("ds: bitset index must not be negative")
+	}
+	return index / bitsPerWord, 1 << (uint(index) % bitsPerWord)
+}
@@ -0,0 +1,218 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("BitSet", func() {
+	var bitSet *ds.BitSet
+
+	BeforeEach(func() {
+		bitSet = ds.NewBitSet(0)
+	})
+
+	It("is empty by default", func() {
+		Expect(bitSet.IsEmpty()).To(BeTrue())
+		Expect(bitSet.Count()).To(BeZero())
+		Expect(bitSet.Test(5)).To(BeFalse())
+	})
+
+	It("panics on negative indices", func() {
+		Expect(func() { bitSet.Set(-1) }).To(Panic())
+		Expect(func() { bitSet.Test(-1) }).To(Panic())
+	})
+
+	When("bits are set", func() {
+		BeforeEach(func() {
+			bitSet.Set(1)
+			bitSet.Set(63)
+			bitSet.Set(64)
+			bitSet.Set(200)
+		})
+
+		It("is no longer empty", func() {
+			Expect(bitSet.IsEmpty()).To(BeFalse())
+		})
+
+		It("counts the set bits", func() {
+			Expect(bitSet.Count()).To(Equal(4))
+		})
+
+		It("reports the set bits", func() {
+			Expect(bitSet.Test(1)).To(BeTrue())
+			Expect(bitSet.Test(2)).To(BeFalse())
+			Expect(bitSet.Test(63)).To(BeTrue())
+			Expect(bitSet.Test(64)).To(BeTrue())
+			Expect(bitSet.Test(200)).To(BeTrue())
+			Expect(bitSet.Test(1000)).To(BeFalse())
+		})
+
+		It("iterates the set bits in order", func() {
+			Expect(slices.Collect(bitSet.All())).To(Equal([]int{1, 63, 64, 200}))
+			Expect(bitSet.Items()).To(Equal([]int{1, 63, 64, 200}))
+		})
+
+		It("finds the next set bit", func() {
+			index, ok := bitSet.NextSet(0)
+			Expect(ok).To(BeTrue())
+			Expect(index).To(Equal(1))
+			index, ok = bitSet.NextSet(63)
+			Expect(ok).To(BeTrue())
+			Expect(index).To(Equal(63))
+			index, ok = bitSet.NextSet(65)
+			Expect(ok).To(BeTrue())
+			Expect(index).To(Equal(200))
+			_, ok = bitSet.NextSet(201)
+			Expect(ok).To(BeFalse())
+			_, ok = bitSet.NextSet(5000)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("is possible to clear bits", func() {
+			bitSet.Clear(63)
+			bitSet.Clear(5000)
+			Expect(bitSet.Items()).To(Equal([]int{1, 64, 200}))
+		})
+
+		It("is possible to flip bits", func() {
+			bitSet.Flip(1)
+			bitSet.Flip(2)
+			Expect(bitSet.Items()).To(Equal([]int{2, 63, 64, 200}))
+		})
+
+		It("equals a set with the same bits", func() {
+			other := ds.BitSetFromSlice([]int{200, 64, 63, 1})
+			other.Set(500)
+			other.Clear(500)
+			Expect(bitSet.Equals(other)).To(BeTrue())
+			Expect(bitSet.Equals(ds.NewBitSet(0))).To(BeFalse())
+		})
+
+		It("remains the same when clipped", func() {
+			bitSet.Clear(200)
+			bitSet.Clip()
+			Expect(bitSet.Items()).To(Equal([]int{1, 63, 64}))
+		})
+
+		When("all bits are cleared", func() {
+			BeforeEach(func() {
+				bitSet.ClearAll()
+			})
+
+			It("becomes empty", func() {
+				Expect(bitSet.IsEmpty()).To(BeTrue())
+				Expect(bitSet.Count()).To(BeZero())
+			})
+
+			It("does not resurrect old bits when growing", func() {
+				bitSet.Set(300)
+				Expect(bitSet.Items()).To(Equal([]int{300}))
+			})
+		})
+	})
+
+	Describe("set operations", func() {
+		var first, second *ds.BitSet
+
+		BeforeEach(func() {
+			first = ds.BitSetFromSlice([]int{1, 2, 3, 100})
+			second = ds.BitSetFromSlice([]int{2, 3, 4, 300})
+		})
+
+		It("produces a union", func() {
+			Expect(ds.BitSetUnion(first, second).Items()).To(Equal([]int{1, 2, 3, 4, 100, 300}))
+			first.Union(second)
+			Expect(first.Items()).To(Equal([]int{1, 2, 3, 4, 100, 300}))
+		})
+
+		It("produces an intersection", func() {
+			Expect(ds.BitSetIntersection(first, second).Items()).To(Equal([]int{2, 3}))
+			first.Intersection(second)
+			Expect(first.Items()).To(Equal([]int{2, 3}))
+		})
+
+		It("produces a difference", func() {
+			Expect(ds.BitSetDifference(first, second).Items()).To(Equal([]int{1, 100}))
+			first.Difference(second)
+			Expect(first.Items()).To(Equal([]int{1, 100}))
+		})
+
+		It("produces a symmetric difference", func() {
+			Expect(ds.BitSetSymmetricDifference(first, second).Items()).To(Equal([]int{1, 4, 100, 300}))
+			first.SymmetricDifference(second)
+			Expect(first.Items()).To(Equal([]int{1, 4, 100, 300}))
+		})
+
+		It("does not modify the operands of new value operations", func() {
+			ds.BitSetUnion(first, second)
+			Expect(first.Items()).To(Equal([]int{1, 2, 3, 100}))
+			Expect(second.Items()).To(Equal([]int{2, 3, 4, 300}))
+		})
+	})
+
+	Describe("marshaling", func() {
+		BeforeEach(func() {
+			bitSet = ds.BitSetFromSlice([]int{0, 5, 70})
+		})
+
+		It("round-trips through binary encoding", func() {
+			data, err := bitSet.MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(HaveLen(16))
+
+			restored := ds.NewBitSet(0)
+			Expect(restored.UnmarshalBinary(data)).To(Succeed())
+			Expect(restored.Equals(bitSet)).To(BeTrue())
+		})
+
+		It("rejects malformed binary data", func() {
+			Expect(bitSet.UnmarshalBinary([]byte{1, 2, 3})).ToNot(Succeed())
+		})
+
+		It("round-trips through JSON", func() {
+			data, err := json.Marshal(bitSet)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[0,5,70]`))
+
+			var restored ds.BitSet
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Equals(bitSet)).To(BeTrue())
+		})
+
+		It("rejects negative indices in JSON", func() {
+			Expect(json.Unmarshal([]byte(`[-1]`), bitSet)).ToNot(Succeed())
+		})
+
+		It("round-trips through text encoding", func() {
+			set := ds.BitSetFromSlice([]int{5, 1, 130})
+			text, err := set.MarshalText()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(text)).To(Equal("1,5,130"))
+			var restored ds.BitSet
+			Expect(restored.UnmarshalText(text)).To(Succeed())
+			Expect(restored.Equals(set)).To(BeTrue())
+		})
+
+		It("encodes an empty set as empty text", func() {
+			var set ds.BitSet
+			text, err := set.MarshalText()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text).To(BeEmpty())
+			restored := ds.BitSetFromSlice([]int{3})
+			Expect(restored.UnmarshalText(text)).To(Succeed())
+			Expect(restored.IsEmpty()).To(BeTrue())
+		})
+
+		It("fails to unmarshal invalid text", func() {
+			var set ds.BitSet
+			Expect(set.UnmarshalText([]byte("1,x"))).ToNot(Succeed())
+			Expect(set.UnmarshalText([]byte("-1"))).ToNot(Succeed())
+		})
+	})
+})
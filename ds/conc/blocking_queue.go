@@ -0,0 +1,98 @@
+package conc
+
+import "context"
+
+// NewBlockingQueue creates a new BlockingQueue instance that can hold up to
+// the specified capacity of items.
+//
+// This function panics if the capacity is not positive.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity <= 0 {
+		panic("conc: blocking queue capacity must be positive")
+	}
+	return &BlockingQueue[T]{
+		items: make(chan T, capacity),
+	}
+}
+
+// BlockingQueue is a bounded thread-safe FIFO queue, where consumers can
+// wait for items to become available and producers can wait for space to
+// become available.
+type BlockingQueue[T any] struct {
+	items chan T
+}
+
+// Size returns the number of items stored in this BlockingQueue.
+func (q *BlockingQueue[T]) Size() int {
+	return len(q.items)
+}
+
+// Capacity returns the maximum number of items that this BlockingQueue can
+// hold.
+func (q *BlockingQueue[T]) Capacity() int {
+	return cap(q.items)
+}
+
+// IsEmpty returns true if there are no items in this BlockingQueue.
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	return len(q.items) == 0
+}
+
+// Offer adds an item to the back of this BlockingQueue without blocking.
+// This method returns false if the BlockingQueue is full.
+func (q *BlockingQueue[T]) Offer(v T) bool {
+	select {
+	case q.items <- v:
+		return true
+	default:
+		return false
+	}
+}
+
+// Push adds an item to the back of this BlockingQueue, waiting for space to
+// become available if it is full. An error is returned if the context is
+// done before the item could be added.
+func (q *BlockingQueue[T]) Push(ctx context.Context, v T) error {
+	select {
+	case q.items <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pop removes the item from the front of this BlockingQueue without
+// blocking. The second return value is false if the BlockingQueue is empty.
+func (q *BlockingQueue[T]) Pop() (T, bool) {
+	select {
+	case v := <-q.items:
+		return v, true
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+// Take removes the item from the front of this BlockingQueue, waiting for
+// one to become available if it is empty. An error is returned if the
+// context is done before an item could be taken.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	select {
+	case v := <-q.items:
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Clear removes all items that are currently in this BlockingQueue.
+func (q *BlockingQueue[T]) Clear() {
+	for {
+		select {
+		case <-q.items:
+		default:
+			return
+		}
+	}
+}
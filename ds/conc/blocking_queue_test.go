@@ -0,0 +1,96 @@
+package conc_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds/conc"
+)
+
+var _ = Describe("BlockingQueue", func() {
+	var queue *conc.BlockingQueue[int]
+
+	BeforeEach(func() {
+		queue = conc.NewBlockingQueue[int](2)
+	})
+
+	It("panics when created with non-positive capacity", func() {
+		Expect(func() { conc.NewBlockingQueue[int](0) }).To(Panic())
+	})
+
+	It("is empty by default", func() {
+		Expect(queue.IsEmpty()).To(BeTrue())
+		Expect(queue.Size()).To(BeZero())
+		Expect(queue.Capacity()).To(Equal(2))
+	})
+
+	It("rejects offers when full", func() {
+		Expect(queue.Offer(1)).To(BeTrue())
+		Expect(queue.Offer(2)).To(BeTrue())
+		Expect(queue.Offer(3)).To(BeFalse())
+		Expect(queue.Size()).To(Equal(2))
+	})
+
+	It("returns items in FIFO order", func() {
+		queue.Offer(1)
+		queue.Offer(2)
+		value, ok := queue.Pop()
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(1))
+		value, err := queue.Take(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(2))
+		_, ok = queue.Pop()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("stops waiting to take when the context is done", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := queue.Take(ctx)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("stops waiting to push when the context is done", func() {
+		queue.Offer(1)
+		queue.Offer(2)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(queue.Push(ctx, 3)).To(MatchError(context.Canceled))
+	})
+
+	It("can be cleared", func() {
+		queue.Offer(1)
+		queue.Offer(2)
+		queue.Clear()
+		Expect(queue.IsEmpty()).To(BeTrue())
+	})
+
+	It("hands items from producers to consumers", func() {
+		ctx := context.Background()
+		var (
+			wg  sync.WaitGroup
+			sum int
+		)
+		wg.Go(func() {
+			defer GinkgoRecover()
+			for i := 1; i <= 100; i++ {
+				Expect(queue.Push(ctx, i)).To(Succeed())
+			}
+		})
+		wg.Go(func() {
+			defer GinkgoRecover()
+			for range 100 {
+				value, err := queue.Take(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				sum += value
+			}
+		})
+		wg.Wait()
+		Expect(sum).To(Equal(5050))
+	})
+})
@@ -0,0 +1,9 @@
+// Package conc provides thread-safe variants of some of the data structures
+// in the ds package.
+//
+// The types in this package expose the same method names as their
+// non-concurrent counterparts. Methods that would panic on an empty
+// collection in the ds package (e.g. Pop) instead report success through an
+// additional boolean return value, since checking for emptiness beforehand
+// is not reliable when other goroutines modify the collection.
+package conc
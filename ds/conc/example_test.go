@@ -0,0 +1,42 @@
+package conc_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mokiat/gog/ds/conc"
+)
+
+func ExampleBlockingQueue() {
+	jobs := conc.NewBlockingQueue[string](10)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for range 2 {
+			job, err := jobs.Take(context.Background())
+			if err != nil {
+				return
+			}
+			fmt.Println("processing", job)
+		}
+	}()
+
+	jobs.Offer("resize image")
+	jobs.Offer("send email")
+	<-done
+
+	// Output:
+	// processing resize image
+	// processing send email
+}
+
+func ExampleMap() {
+	sessions := conc.NewMap[string, int](0)
+	sessions.Set("alice", 1)
+	value, loaded := sessions.GetOrSet("alice", 2)
+	fmt.Println(value, loaded)
+
+	// Output:
+	// 1 true
+}
@@ -0,0 +1,116 @@
+package conc
+
+import (
+	"iter"
+	"sync"
+)
+
+// NewMap creates a new Map instance with the specified initial capacity,
+// which is only used to preallocate memory and does not act as an upper
+// bound.
+func NewMap[K comparable, V any](initialCapacity int) *Map[K, V] {
+	return &Map[K, V]{
+		items: make(map[K]V, initialCapacity),
+	}
+}
+
+// Map is a thread-safe map. Unlike sync.Map, it is typed and does not
+// require type assertions.
+type Map[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]V
+}
+
+// IsEmpty returns true if there are no entries in this Map.
+func (m *Map[K, V]) IsEmpty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.items) == 0
+}
+
+// Size returns the number of entries stored in this Map.
+func (m *Map[K, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.items)
+}
+
+// Set stores the specified value for the specified key, replacing any
+// previous value.
+func (m *Map[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = value
+}
+
+// Get returns the value stored for the specified key. The second return
+// value is false if there is no such key.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	value, ok := m.items[key]
+	return value, ok
+}
+
+// GetOrSet returns the existing value for the specified key, if present.
+// Otherwise, it stores and returns the specified value. The second return
+// value is true if the value was already present.
+func (m *Map[K, V]) GetOrSet(key K, value V) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.items[key]; ok {
+		return existing, true
+	}
+	m.items[key] = value
+	return value, false
+}
+
+// Has returns whether this Map contains the specified key.
+func (m *Map[K, V]) Has(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.items[key]
+	return ok
+}
+
+// Delete removes the specified key from this Map. This method returns true
+// if there was such a key and false otherwise.
+func (m *Map[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.items[key]; !ok {
+		return false
+	}
+	delete(m.items, key)
+	return true
+}
+
+// All returns a sequence over a snapshot of the entries in this Map, taken
+// when the iteration starts. The Map can be safely modified during
+// iteration.
+//
+// Note: The entries are returned in a random order.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.RLock()
+		keys := make([]K, 0, len(m.items))
+		values := make([]V, 0, len(m.items))
+		for key, value := range m.items {
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		m.mu.RUnlock()
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all entries from this Map.
+func (m *Map[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.items)
+}
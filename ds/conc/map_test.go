@@ -0,0 +1,69 @@
+package conc_test
+
+import (
+	"maps"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds/conc"
+)
+
+var _ = Describe("Map", func() {
+	var concMap *conc.Map[string, int]
+
+	BeforeEach(func() {
+		concMap = conc.NewMap[string, int](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(concMap.IsEmpty()).To(BeTrue())
+		Expect(concMap.Size()).To(BeZero())
+	})
+
+	It("supports basic map operations", func() {
+		concMap.Set("a", 1)
+		concMap.Set("b", 2)
+		value, ok := concMap.Get("a")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(1))
+		Expect(concMap.Has("b")).To(BeTrue())
+		Expect(maps.Collect(concMap.All())).To(Equal(map[string]int{"a": 1, "b": 2}))
+		Expect(concMap.Delete("a")).To(BeTrue())
+		Expect(concMap.Delete("a")).To(BeFalse())
+		Expect(concMap.Size()).To(Equal(1))
+		concMap.Clear()
+		Expect(concMap.IsEmpty()).To(BeTrue())
+	})
+
+	It("only sets missing keys with GetOrSet", func() {
+		value, loaded := concMap.GetOrSet("a", 1)
+		Expect(loaded).To(BeFalse())
+		Expect(value).To(Equal(1))
+		value, loaded = concMap.GetOrSet("a", 2)
+		Expect(loaded).To(BeTrue())
+		Expect(value).To(Equal(1))
+	})
+
+	It("is safe for concurrent use", func() {
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			winners int
+		)
+		for range 8 {
+			wg.Go(func() {
+				if _, loaded := concMap.GetOrSet("shared", 1); !loaded {
+					mu.Lock()
+					winners++
+					mu.Unlock()
+				}
+				for range concMap.All() {
+				}
+			})
+		}
+		wg.Wait()
+		Expect(winners).To(Equal(1))
+	})
+})
@@ -0,0 +1,75 @@
+package conc
+
+import (
+	"sync"
+
+	"github.com/mokiat/gog/ds"
+)
+
+// NewQueue creates a new Queue instance with the specified initial capacity,
+// which only serves to preallocate memory. Exceeding the initial capacity is
+// allowed.
+func NewQueue[T any](initCapacity int) *Queue[T] {
+	return &Queue[T]{
+		items: ds.NewQueue[T](initCapacity),
+	}
+}
+
+// Queue is a thread-safe variant of ds.Queue. It never blocks; use
+// BlockingQueue if consumers need to wait for items.
+type Queue[T any] struct {
+	mu    sync.Mutex
+	items *ds.Queue[T]
+}
+
+// Size returns the number of items stored in this Queue.
+func (q *Queue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Size()
+}
+
+// IsEmpty returns true if there are no more items in this Queue.
+func (q *Queue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.IsEmpty()
+}
+
+// Push adds an item to the back of this Queue.
+func (q *Queue[T]) Push(v T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items.Push(v)
+}
+
+// Pop removes the item from the front of this Queue and returns it. The
+// second return value is false if the Queue is empty.
+func (q *Queue[T]) Pop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.items.IsEmpty() {
+		var zero T
+		return zero, false
+	}
+	return q.items.Pop(), true
+}
+
+// Peek returns the item that is at the front of this Queue without removing
+// it. The second return value is false if the Queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.items.IsEmpty() {
+		var zero T
+		return zero, false
+	}
+	return q.items.Peek(), true
+}
+
+// Clear removes all items from this Queue.
+func (q *Queue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items.Clear()
+}
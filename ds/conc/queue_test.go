@@ -0,0 +1,76 @@
+package conc_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds/conc"
+)
+
+var _ = Describe("Queue", func() {
+	var queue *conc.Queue[int]
+
+	BeforeEach(func() {
+		queue = conc.NewQueue[int](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(queue.IsEmpty()).To(BeTrue())
+		Expect(queue.Size()).To(BeZero())
+		_, ok := queue.Pop()
+		Expect(ok).To(BeFalse())
+		_, ok = queue.Peek()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("returns items in FIFO order", func() {
+		queue.Push(1)
+		queue.Push(2)
+		value, ok := queue.Peek()
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(1))
+		value, _ = queue.Pop()
+		Expect(value).To(Equal(1))
+		value, _ = queue.Pop()
+		Expect(value).To(Equal(2))
+	})
+
+	It("can be cleared", func() {
+		queue.Push(1)
+		queue.Clear()
+		Expect(queue.IsEmpty()).To(BeTrue())
+	})
+
+	It("is safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Go(func() {
+				for i := range 100 {
+					queue.Push(i)
+				}
+			})
+		}
+		wg.Wait()
+
+		var (
+			mu    sync.Mutex
+			count int
+		)
+		for range 4 {
+			wg.Go(func() {
+				for {
+					if _, ok := queue.Pop(); !ok {
+						return
+					}
+					mu.Lock()
+					count++
+					mu.Unlock()
+				}
+			})
+		}
+		wg.Wait()
+		Expect(count).To(Equal(400))
+	})
+})
@@ -0,0 +1,92 @@
+package conc
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/mokiat/gog/ds"
+)
+
+// NewSet creates a new Set instance with the specified initial capacity,
+// which is only used to preallocate memory and does not act as an upper
+// bound.
+func NewSet[T comparable](initialCapacity int) *Set[T] {
+	return &Set[T]{
+		items: ds.NewSet[T](initialCapacity),
+	}
+}
+
+// Set is a thread-safe variant of ds.Set.
+type Set[T comparable] struct {
+	mu    sync.RWMutex
+	items *ds.Set[T]
+}
+
+// IsEmpty returns whether this Set is empty.
+func (s *Set[T]) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items.IsEmpty()
+}
+
+// Size returns the number of items contained in this Set.
+func (s *Set[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items.Size()
+}
+
+// Add adds the specified item to this Set if it was not present already.
+// This method returns true if the operation was performed and false if
+// the item was already present.
+func (s *Set[T]) Add(item T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.items.Add(item)
+}
+
+// Remove removes the specified item from this Set.
+// This method returns true if there was in fact such an item to be removed
+// and false otherwise.
+func (s *Set[T]) Remove(item T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.items.Remove(item)
+}
+
+// Contains returns whether this Set holds the specified item.
+func (s *Set[T]) Contains(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items.Contains(item)
+}
+
+// Items returns a slice containing all of the items from this Set.
+//
+// Note: The items are returned in a random order which can differ
+// between subsequent calls.
+func (s *Set[T]) Items() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items.Items()
+}
+
+// All returns a sequence over a snapshot of the items in this Set, taken
+// when the iteration starts. The Set can be safely modified during
+// iteration.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.Items() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all items from this Set.
+func (s *Set[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items.Clear()
+}
@@ -0,0 +1,61 @@
+package conc_test
+
+import (
+	"slices"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds/conc"
+)
+
+var _ = Describe("Set", func() {
+	var set *conc.Set[int]
+
+	BeforeEach(func() {
+		set = conc.NewSet[int](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(set.IsEmpty()).To(BeTrue())
+		Expect(set.Size()).To(BeZero())
+	})
+
+	It("supports basic set operations", func() {
+		Expect(set.Add(1)).To(BeTrue())
+		Expect(set.Add(1)).To(BeFalse())
+		Expect(set.Add(2)).To(BeTrue())
+		Expect(set.Contains(1)).To(BeTrue())
+		Expect(set.Items()).To(ConsistOf(1, 2))
+		Expect(slices.Collect(set.All())).To(ConsistOf(1, 2))
+		Expect(set.Remove(1)).To(BeTrue())
+		Expect(set.Remove(1)).To(BeFalse())
+		set.Clear()
+		Expect(set.IsEmpty()).To(BeTrue())
+	})
+
+	It("allows modification during iteration", func() {
+		set.Add(1)
+		set.Add(2)
+		for item := range set.All() {
+			set.Remove(item)
+		}
+		Expect(set.IsEmpty()).To(BeTrue())
+	})
+
+	It("is safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for worker := range 8 {
+			wg.Go(func() {
+				for i := range 100 {
+					set.Add(worker*100 + i)
+					set.Contains(i)
+					set.Size()
+				}
+			})
+		}
+		wg.Wait()
+		Expect(set.Size()).To(Equal(800))
+	})
+})
@@ -0,0 +1,13 @@
+package conc_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestConc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Concurrent Data Structures Suite")
+}
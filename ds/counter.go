@@ -0,0 +1,269 @@
+package ds
+
+import (
+	"errors"
+	"iter"
+)
+
+// NewCounter creates a new Counter instance with the specified initial
+// capacity, which is only used to preallocate memory and does not act as an
+// upper bound.
+func NewCounter[T comparable](initialCapacity int) *Counter[T] {
+	return &Counter[T]{
+		counts: make(map[T]int, initialCapacity),
+	}
+}
+
+// CounterFromSlice creates a new Counter instance that counts the
+// occurrences of the elements contained in the provided slice.
+func CounterFromSlice[T comparable](slice []T) *Counter[T] {
+	result := NewCounter[T](len(slice))
+	for _, item := range slice {
+		result.Add(item, 1)
+	}
+	return result
+}
+
+// CounterFromSeq creates a new Counter instance that counts the occurrences
+// of the elements in the provided sequence.
+func CounterFromSeq[T comparable](src iter.Seq[T]) *Counter[T] {
+	result := NewCounter[T](0)
+	for item := range src {
+		result.Add(item, 1)
+	}
+	return result
+}
+
+// CounterUnion creates a new Counter where the count of each item is the
+// maximum of its counts in the specified counters.
+func CounterUnion[T comparable](first, second *Counter[T]) *Counter[T] {
+	result := NewCounter[T](max(len(first.counts), len(second.counts)))
+	for item, count := range first.counts {
+		result.counts[item] = count
+	}
+	for item, count := range second.counts {
+		result.counts[item] = max(result.counts[item], count)
+	}
+	result.total = sumCounts(result.counts)
+	return result
+}
+
+// CounterIntersection creates a new Counter where the count of each item is
+// the minimum of its counts in the specified counters. Items that are not
+// present in both counters are omitted.
+func CounterIntersection[T comparable](first, second *Counter[T]) *Counter[T] {
+	result := NewCounter[T](0)
+	for item, count := range first.counts {
+		if otherCount, ok := second.counts[item]; ok {
+			result.counts[item] = min(count, otherCount)
+		}
+	}
+	result.total = sumCounts(result.counts)
+	return result
+}
+
+// CounterDifference creates a new Counter where the count of each item is
+// its count in the first counter minus its count in the second one. Items
+// that end up with a count of zero or less are omitted.
+func CounterDifference[T comparable](first, second *Counter[T]) *Counter[T] {
+	result := NewCounter[T](len(first.counts))
+	for item, count := range first.counts {
+		if count -= second.counts[item]; count > 0 {
+			result.counts[item] = count
+		}
+	}
+	result.total = sumCounts(result.counts)
+	return result
+}
+
+// CounterEntry represents an item and the number of its occurrences.
+type CounterEntry[T comparable] struct {
+	// Item holds the item that is counted.
+	Item T `json:"item"`
+
+	// Count holds the number of occurrences of the item.
+	Count int `json:"count"`
+}
+
+// Counter represents a multiset, which keeps track of the number of
+// occurrences of each item. It is a more readable replacement of the
+// map[T]int pattern.
+//
+// Only items with a positive count are stored.
+type Counter[T comparable] struct {
+	counts map[T]int
+	total  int
+}
+
+// IsEmpty returns whether this Counter has no items.
+func (c *Counter[T]) IsEmpty() bool {
+	return len(c.counts) == 0
+}
+
+// Size returns the number of distinct items in this Counter.
+func (c *Counter[T]) Size() int {
+	return len(c.counts)
+}
+
+// Total returns the sum of the counts of all items in this Counter.
+func (c *Counter[T]) Total() int {
+	return c.total
+}
+
+// Add increases the count of the specified item by n. A non-positive n
+// has no effect.
+func (c *Counter[T]) Add(item T, n int) {
+	if n <= 0 {
+		return
+	}
+	c.counts[item] += n
+	c.total += n
+}
+
+// Remove decreases the count of the specified item by n. The count does not
+// go below zero and items that reach zero are removed. This method returns
+// the number of occurrences that were in fact removed.
+func (c *Counter[T]) Remove(item T, n int) int {
+	count, ok := c.counts[item]
+	if !ok || n <= 0 {
+		return 0
+	}
+	if n >= count {
+		delete(c.counts, item)
+		c.total -= count
+		return count
+	}
+	c.counts[item] = count - n
+	c.total -= n
+	return n
+}
+
+// Count returns the number of occurrences of the specified item.
+func (c *Counter[T]) Count(item T) int {
+	return c.counts[item]
+}
+
+// Contains returns whether the specified item has a positive count.
+func (c *Counter[T]) Contains(item T) bool {
+	_, ok := c.counts[item]
+	return ok
+}
+
+// MostCommon returns up to k items with the highest counts, sorted from the
+// most to the least common. The order of items with equal counts is
+// unspecified.
+func (c *Counter[T]) MostCommon(k int) []CounterEntry[T] {
+	topK := NewTopK(min(k, len(c.counts)), func(a, b CounterEntry[T]) bool {
+		return a.Count > b.Count
+	})
+	for item, count := range c.counts {
+		topK.Push(CounterEntry[T]{
+			Item:  item,
+			Count: count,
+		})
+	}
+	return topK.Items()
+}
+
+// All returns a sequence over all items in this Counter and their counts.
+//
+// Note: The items are returned in a random order.
+func (c *Counter[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for item, count := range c.counts {
+			if !yield(item, count) {
+				return
+			}
+		}
+	}
+}
+
+// Items returns a slice containing all of the distinct items from this
+// Counter.
+//
+// Note: The items are returned in a random order which can differ
+// between subsequent calls.
+func (c *Counter[T]) Items() []T {
+	result := make([]T, 0, len(c.counts))
+	for item := range c.counts {
+		result = append(result, item)
+	}
+	return result
+}
+
+// Clear removes all items from this Counter.
+func (c *Counter[T]) Clear() {
+	clear(c.counts)
+	c.total = 0
+}
+
+// MarshalJSON encodes this Counter as a JSON array of CounterEntry objects.
+// If the items are of an integer, float or string type, the entries are
+// sorted by item so that the output is deterministic.
+func (c *Counter[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(c.entries())
+}
+
+// UnmarshalJSON decodes a JSON array of CounterEntry objects into this
+// Counter. Existing items are removed beforehand and the counts of
+// repeated items are summed up.
+func (c *Counter[T]) UnmarshalJSON(data []byte) error {
+	entries, err := unmarshalItemsJSON[CounterEntry[T]](data)
+	if err != nil {
+		return err
+	}
+	return c.replace(entries)
+}
+
+// MarshalBinary encodes this Counter using encoding/gob. If the items are
+// of an integer, float or string type, they are sorted in ascending order
+// so that the output is deterministic.
+func (c *Counter[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(c.entries())
+}
+
+// UnmarshalBinary decodes a Counter that was encoded with MarshalBinary.
+// Existing items are removed beforehand.
+func (c *Counter[T]) UnmarshalBinary(data []byte) error {
+	entries, err := unmarshalItemsBinary[CounterEntry[T]](data)
+	if err != nil {
+		return err
+	}
+	return c.replace(entries)
+}
+
+func (c *Counter[T]) entries() []CounterEntry[T] {
+	result := make([]CounterEntry[T], 0, len(c.counts))
+	for item, count := range c.counts {
+		result = append(result, CounterEntry[T]{
+			Item:  item,
+			Count: count,
+		})
+	}
+	sortByOrderedKey(result, func(entry CounterEntry[T]) T {
+		return entry.Item
+	})
+	return result
+}
+
+func (c *Counter[T]) replace(entries []CounterEntry[T]) error {
+	if c.counts == nil {
+		c.counts = make(map[T]int, len(entries))
+	}
+	c.Clear()
+	for _, entry := range entries {
+		if entry.Count <= 0 {
+			return errors.New("counter count must be positive")
+		}
+		c.Add(entry.Item, entry.Count)
+	}
+	return nil
+}
+
+func sumCounts[T comparable](counts map[T]int) int {
+	var result int
+	for _, count := range counts {
+		result += count
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("Counter", func() {
+	var counter *ds.Counter[string]
+
+	BeforeEach(func() {
+		counter = ds.NewCounter[string](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(counter.IsEmpty()).To(BeTrue())
+		Expect(counter.Size()).To(BeZero())
+		Expect(counter.Total()).To(BeZero())
+		Expect(counter.Count("missing")).To(BeZero())
+	})
+
+	When("items are added", func() {
+		BeforeEach(func() {
+			counter.Add("apple", 3)
+			counter.Add("pear", 1)
+			counter.Add("plum", 5)
+			counter.Add("apple", 2)
+			counter.Add("fig", 0)
+		})
+
+		It("counts the items", func() {
+			Expect(counter.Count("apple")).To(Equal(5))
+			Expect(counter.Count("pear")).To(Equal(1))
+			Expect(counter.Count("plum")).To(Equal(5))
+		})
+
+		It("ignores non-positive additions", func() {
+			Expect(counter.Contains("fig")).To(BeFalse())
+		})
+
+		It("has the correct size and total", func() {
+			Expect(counter.Size()).To(Equal(3))
+			Expect(counter.Total()).To(Equal(11))
+		})
+
+		It("iterates the items and counts", func() {
+			Expect(maps.Collect(counter.All())).To(Equal(map[string]int{
+				"apple": 5, "pear": 1, "plum": 5,
+			}))
+			Expect(counter.Items()).To(ConsistOf("apple", "pear", "plum"))
+		})
+
+		It("is possible to remove occurrences", func() {
+			Expect(counter.Remove("apple", 2)).To(Equal(2))
+			Expect(counter.Count("apple")).To(Equal(3))
+			Expect(counter.Total()).To(Equal(9))
+		})
+
+		It("removes items that reach zero", func() {
+			Expect(counter.Remove("pear", 10)).To(Equal(1))
+			Expect(counter.Contains("pear")).To(BeFalse())
+			Expect(counter.Size()).To(Equal(2))
+			Expect(counter.Total()).To(Equal(10))
+		})
+
+		It("returns the most common items", func() {
+			counter.Add("plum", 1)
+			Expect(counter.MostCommon(2)).To(Equal([]ds.CounterEntry[string]{
+				{Item: "plum", Count: 6},
+				{Item: "apple", Count: 5},
+			}))
+			Expect(counter.MostCommon(10)).To(HaveLen(3))
+			Expect(counter.MostCommon(0)).To(BeEmpty())
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				counter.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(counter.IsEmpty()).To(BeTrue())
+				Expect(counter.Total()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("constructors", func() {
+		It("counts the items of a slice", func() {
+			counter := ds.CounterFromSlice([]string{"a", "b", "a"})
+			Expect(counter.Count("a")).To(Equal(2))
+			Expect(counter.Count("b")).To(Equal(1))
+			Expect(counter.Total()).To(Equal(3))
+		})
+
+		It("counts the items of a sequence", func() {
+			counter := ds.CounterFromSeq(slices.Values([]string{"x", "x", "y"}))
+			Expect(counter.Count("x")).To(Equal(2))
+			Expect(counter.Count("y")).To(Equal(1))
+		})
+	})
+
+	Describe("multiset operations", func() {
+		var first, second *ds.Counter[string]
+
+		BeforeEach(func() {
+			first = ds.CounterFromSlice([]string{"a", "a", "a", "b", "c"})
+			second = ds.CounterFromSlice([]string{"a", "b", "b", "d"})
+		})
+
+		It("produces a union", func() {
+			result := ds.CounterUnion(first, second)
+			Expect(maps.Collect(result.All())).To(Equal(map[string]int{
+				"a": 3, "b": 2, "c": 1, "d": 1,
+			}))
+			Expect(result.Total()).To(Equal(7))
+		})
+
+		It("produces an intersection", func() {
+			result := ds.CounterIntersection(first, second)
+			Expect(maps.Collect(result.All())).To(Equal(map[string]int{
+				"a": 1, "b": 1,
+			}))
+			Expect(result.Total()).To(Equal(2))
+		})
+
+		It("produces a difference", func() {
+			result := ds.CounterDifference(first, second)
+			Expect(maps.Collect(result.All())).To(Equal(map[string]int{
+				"a": 2, "c": 1,
+			}))
+			Expect(result.Total()).To(Equal(3))
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("marshals to a sorted JSON array of entries", func() {
+			counter := ds.CounterFromSlice([]string{"b", "a", "b"})
+			data, err := json.Marshal(counter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[{"item":"a","count":1},{"item":"b","count":2}]`))
+		})
+
+		It("unmarshals into a zero value", func() {
+			var counter ds.Counter[string]
+			Expect(json.Unmarshal([]byte(`[{"item":"a","count":2},{"item":"a","count":1}]`), &counter)).To(Succeed())
+			Expect(counter.Count("a")).To(Equal(3))
+			Expect(counter.Total()).To(Equal(3))
+		})
+
+		It("fails to unmarshal non-positive counts", func() {
+			var counter ds.Counter[string]
+			Expect(json.Unmarshal([]byte(`[{"item":"a","count":0}]`), &counter)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			var restored ds.Counter[int]
+			gobRoundTrip(ds.CounterFromSlice([]int{1, 2, 2}), &restored)
+			Expect(restored.Count(1)).To(Equal(1))
+			Expect(restored.Count(2)).To(Equal(2))
+			Expect(restored.Total()).To(Equal(3))
+		})
+	})
+})
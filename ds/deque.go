@@ -0,0 +1,212 @@
+package ds
+
+import "iter"
+
+// NewDeque creates a new Deque instance with the specified initial capacity,
+// which only serves to preallocate memory. Exceeding the initial capacity is
+// allowed.
+func NewDeque[T any](initCapacity int) *Deque[T] {
+	return &Deque[T]{
+		items: make([]T, initCapacity),
+	}
+}
+
+// Deque is an implementation of a double-ended queue data structure. Items
+// can be added and removed from both the front and the back.
+//
+// The Deque is backed by a ring buffer, which means that no memory
+// allocations occur as long as the size stays within the capacity.
+type Deque[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+// Size returns the number of items stored in this Deque.
+func (d *Deque[T]) Size() int {
+	return d.size
+}
+
+// IsEmpty returns true if there are no more items in this Deque.
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// PushFront adds an item to the front of this Deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.ensureCapacity(d.size + 1)
+	d.head = d.wrap(d.head - 1)
+	d.items[d.head] = v
+	d.size++
+}
+
+// PushBack adds an item to the back of this Deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.ensureCapacity(d.size + 1)
+	d.items[d.wrap(d.head+d.size)] = v
+	d.size++
+}
+
+// PopFront removes the item from the front of this Deque and returns it.
+// This function panics if there are no more items. Use IsEmpty to check
+// for that.
+func (d *Deque[T]) PopFront() T {
+	if d.size == 0 {
+		panic("ds: pop from empty deque")
+	}
+	var zero T
+	result := d.items[d.head]
+	d.items[d.head] = zero
+	d.head = d.wrap(d.head + 1)
+	d.size--
+	return result
+}
+
+// PopBack removes the item from the back of this Deque and returns it.
+// This function panics if there are no more items. Use IsEmpty to check
+// for that.
+func (d *Deque[T]) PopBack() T {
+	if d.size == 0 {
+		panic("ds: pop from empty deque")
+	}
+	var zero T
+	index := d.wrap(d.head + d.size - 1)
+	result := d.items[index]
+	d.items[index] = zero
+	d.size--
+	return result
+}
+
+// PeekFront returns the item that is at the front of this Deque without
+// removing it. Make sure that the Deque is not empty, otherwise this method
+// will panic.
+func (d *Deque[T]) PeekFront() T {
+	if d.size == 0 {
+		panic("ds: peek on empty deque")
+	}
+	return d.items[d.head]
+}
+
+// PeekBack returns the item that is at the back of this Deque without
+// removing it. Make sure that the Deque is not empty, otherwise this method
+// will panic.
+func (d *Deque[T]) PeekBack() T {
+	if d.size == 0 {
+		panic("ds: peek on empty deque")
+	}
+	return d.items[d.wrap(d.head+d.size-1)]
+}
+
+// All returns a sequence over the items in this Deque, starting from the
+// front and ending with the back one.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range d.size {
+			if !yield(d.items[d.wrap(d.head+i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence over the items in this Deque, starting from
+// the back and ending with the front one.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(d.items[d.wrap(d.head+i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all items from this Deque.
+func (d *Deque[T]) Clear() {
+	clear(d.items)
+	d.head = 0
+	d.size = 0
+}
+
+// Clip removes unused capacity from the Deque.
+func (d *Deque[T]) Clip() {
+	d.resize(d.size)
+}
+
+// MarshalJSON encodes this Deque as a JSON array, starting from the front
+// and ending with the back item.
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(d.slice())
+}
+
+// UnmarshalJSON decodes a JSON array into this Deque, replacing any existing
+// items. The first item in the array ends up at the front.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	items, err := unmarshalItemsJSON[T](data)
+	if err != nil {
+		return err
+	}
+	d.replace(items)
+	return nil
+}
+
+// MarshalBinary encodes this Deque using encoding/gob.
+func (d *Deque[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(d.slice())
+}
+
+// UnmarshalBinary decodes a Deque that was encoded with MarshalBinary,
+// replacing any existing items.
+func (d *Deque[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalItemsBinary[T](data)
+	if err != nil {
+		return err
+	}
+	d.replace(items)
+	return nil
+}
+
+func (d *Deque[T]) wrap(index int) int {
+	capacity := len(d.items)
+	index %= capacity
+	if index < 0 {
+		index += capacity
+	}
+	return index
+}
+
+func (d *Deque[T]) ensureCapacity(capacity int) {
+	if capacity <= len(d.items) {
+		return
+	}
+	d.resize(max(capacity, 2*len(d.items), 4))
+}
+
+func (d *Deque[T]) resize(capacity int) {
+	items := make([]T, capacity)
+	if d.size > 0 {
+		tail := d.head + d.size
+		if tail <= len(d.items) {
+			copy(items, d.items[d.head:tail])
+		} else {
+			count := copy(items, d.items[d.head:])
+			copy(items[count:], d.items[:tail-len(d.items)])
+		}
+	}
+	d.items = items
+	d.head = 0
+}
+
+func (d *Deque[T]) slice() []T {
+	result := make([]T, 0, d.size)
+	for item := range d.All() {
+		result = append(result, item)
+	}
+	return result
+}
+
+func (d *Deque[T]) replace(items []T) {
+	d.items = items
+	d.head = 0
+	d.size = len(items)
+}
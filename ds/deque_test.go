@@ -0,0 +1,153 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("Deque", func() {
+	var (
+		deque *ds.Deque[int]
+	)
+
+	BeforeEach(func() {
+		deque = ds.NewDeque[int](2)
+	})
+
+	It("is empty by default", func() {
+		Expect(deque.IsEmpty()).To(BeTrue())
+	})
+
+	It("has zero size by default", func() {
+		Expect(deque.Size()).To(BeZero())
+	})
+
+	It("panics when accessing an empty deque", func() {
+		Expect(func() { deque.PopFront() }).To(Panic())
+		Expect(func() { deque.PopBack() }).To(Panic())
+		Expect(func() { deque.PeekFront() }).To(Panic())
+		Expect(func() { deque.PeekBack() }).To(Panic())
+	})
+
+	When("items are added to both ends", func() {
+		BeforeEach(func() {
+			deque.PushBack(3)
+			deque.PushFront(2)
+			deque.PushBack(4)
+			deque.PushFront(1)
+			deque.PushBack(5)
+		})
+
+		It("is no longer empty", func() {
+			Expect(deque.IsEmpty()).To(BeFalse())
+		})
+
+		It("has the correct size", func() {
+			Expect(deque.Size()).To(Equal(5))
+		})
+
+		It("is possible to peek both ends", func() {
+			Expect(deque.PeekFront()).To(Equal(1))
+			Expect(deque.PeekBack()).To(Equal(5))
+			Expect(deque.Size()).To(Equal(5))
+		})
+
+		It("is possible to iterate the items", func() {
+			Expect(slices.Collect(deque.All())).To(Equal([]int{1, 2, 3, 4, 5}))
+			Expect(slices.Collect(deque.Backward())).To(Equal([]int{5, 4, 3, 2, 1}))
+		})
+
+		It("is possible to fetch items from the front", func() {
+			Expect(deque.PopFront()).To(Equal(1))
+			Expect(deque.PopFront()).To(Equal(2))
+			Expect(deque.PopFront()).To(Equal(3))
+			Expect(deque.PopFront()).To(Equal(4))
+			Expect(deque.PopFront()).To(Equal(5))
+			Expect(deque.IsEmpty()).To(BeTrue())
+		})
+
+		It("is possible to fetch items from the back", func() {
+			Expect(deque.PopBack()).To(Equal(5))
+			Expect(deque.PopBack()).To(Equal(4))
+			Expect(deque.PopBack()).To(Equal(3))
+			Expect(deque.PopBack()).To(Equal(2))
+			Expect(deque.PopBack()).To(Equal(1))
+			Expect(deque.IsEmpty()).To(BeTrue())
+		})
+
+		When("the deque is clipped", func() {
+			BeforeEach(func() {
+				deque.Clip()
+			})
+
+			It("still contains the same items", func() {
+				Expect(deque.PopFront()).To(Equal(1))
+				Expect(deque.PopBack()).To(Equal(5))
+				Expect(deque.PopFront()).To(Equal(2))
+				Expect(deque.PopBack()).To(Equal(4))
+				Expect(deque.PopFront()).To(Equal(3))
+			})
+
+			It("is still possible to add items", func() {
+				deque.PushFront(0)
+				deque.PushBack(6)
+				Expect(deque.PeekFront()).To(Equal(0))
+				Expect(deque.PeekBack()).To(Equal(6))
+				Expect(deque.Size()).To(Equal(7))
+			})
+		})
+
+		When("the deque is cleared", func() {
+			BeforeEach(func() {
+				deque.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(deque.IsEmpty()).To(BeTrue())
+			})
+
+			It("changes its size to zero", func() {
+				Expect(deque.Size()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON", func() {
+			deque := ds.NewDeque[int](0)
+			deque.PushBack(2)
+			deque.PushFront(1)
+			deque.PushBack(3)
+			data, err := json.Marshal(deque)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[1,2,3]`))
+			var restored ds.Deque[int]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.PopBack()).To(Equal(3))
+			Expect(restored.PopFront()).To(Equal(1))
+		})
+
+		It("is usable after unmarshaling an empty array", func() {
+			var restored ds.Deque[int]
+			Expect(json.Unmarshal([]byte(`[]`), &restored)).To(Succeed())
+			Expect(restored.IsEmpty()).To(BeTrue())
+			restored.PushFront(1)
+			Expect(restored.PeekBack()).To(Equal(1))
+		})
+
+		It("round-trips through gob", func() {
+			deque := ds.NewDeque[int](0)
+			deque.PushFront(2)
+			deque.PushFront(1)
+			var restored ds.Deque[int]
+			gobRoundTrip(deque, &restored)
+			Expect(restored.PopFront()).To(Equal(1))
+			Expect(restored.PopFront()).To(Equal(2))
+		})
+	})
+})
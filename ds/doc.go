@@ -6,4 +6,35 @@
 //
 // Now that Go has generics, this package provides some common data structures
 // that make use of this new language feature.
+//
+// # Encoding
+//
+// Most data structures in this package implement json.Marshaler,
+// json.Unmarshaler, encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler. The binary form is based on encoding/gob,
+// which means that the element types need to be supported by it. Unordered
+// collections sort their items when these are of an integer, float or
+// string type, so that the output is deterministic.
+//
+// The zero value of a data structure can be both encoded and decoded into,
+// with the following exceptions:
+//
+//   - RingBuffer and LRU encode their capacity, which needs to be positive
+//     when decoding, just like with NewRingBuffer and NewLRU. This means
+//     that the encoded form of a zero value cannot be decoded.
+//   - SortedSet, SortedMap, Heap and TopK order their items with a function,
+//     which cannot be encoded. They can only be decoded into an instance
+//     that was created through one of their constructors.
+//
+// IndexedHeap and Pool cannot be encoded. The items of an IndexedHeap are
+// referenced through handles held by the caller, which a decoded
+// IndexedHeap would not be able to restore. A Pool holds reusable objects
+// and the functions that create them, rather than data.
+//
+// BitSet and SlotHandle additionally implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler, since both have a compact string form: a BitSet
+// is written as a comma-separated list of its indices and a SlotHandle as
+// its index and generation. Among other things, this allows them to be used
+// as map keys with encoding/json. The other collections do not implement
+// these interfaces, since their items cannot be written as text in general.
 package ds
@@ -0,0 +1,106 @@
+package ds
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// marshalItemsJSON encodes the specified items as a JSON array. Unlike
+// encoding/json, a nil slice is encoded as an empty array.
+func marshalItemsJSON[T any](items []T) ([]byte, error) {
+	if items == nil {
+		items = []T{}
+	}
+	return json.Marshal(items)
+}
+
+// unmarshalItemsJSON decodes a JSON array into a slice of items. A JSON null
+// is decoded as an empty slice.
+func unmarshalItemsJSON[T any](data []byte) ([]T, error) {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// marshalItemsBinary encodes the specified items using encoding/gob.
+func marshalItemsBinary[T any](items []T) ([]byte, error) {
+	return marshalGob(items)
+}
+
+// unmarshalItemsBinary decodes items that were encoded with
+// marshalItemsBinary.
+func unmarshalItemsBinary[T any](data []byte) ([]T, error) {
+	var items []T
+	if err := unmarshalGob(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// marshalGob encodes the specified value using encoding/gob.
+func marshalGob(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(value); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// unmarshalGob decodes a value that was encoded with marshalGob into the
+// specified target pointer.
+func unmarshalGob(data []byte, target any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(target)
+}
+
+// keyValue is the encoded form of a single entry of a map-like data
+// structure.
+type keyValue[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// sortIfOrdered sorts the specified items in ascending order if their
+// underlying type is an integer, float or string. Otherwise the items are
+// left unchanged.
+//
+// This is used to produce deterministic encodings for unordered collections.
+func sortIfOrdered[T any](items []T) {
+	sortByOrderedKey(items, func(item T) T {
+		return item
+	})
+}
+
+// sortByOrderedKey works like sortIfOrdered, except that the items are
+// compared by the key that the specified function returns for them.
+func sortByOrderedKey[E, K any](items []E, key func(E) K) {
+	var compare func(a, b reflect.Value) int
+	switch reflect.TypeFor[K]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.Int(), b.Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.Uint(), b.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.Float(), b.Float())
+		}
+	case reflect.String:
+		compare = func(a, b reflect.Value) int {
+			return cmp.Compare(a.String(), b.String())
+		}
+	default:
+		return
+	}
+	slices.SortFunc(items, func(a, b E) int {
+		return compare(reflect.ValueOf(key(a)), reflect.ValueOf(key(b)))
+	})
+}
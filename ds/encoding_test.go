@@ -0,0 +1,169 @@
+package ds_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+// gobRoundTrip encodes the source with encoding/gob and decodes the result
+// into the target.
+func gobRoundTrip(source, target any) {
+	var buffer bytes.Buffer
+	Expect(gob.NewEncoder(&buffer).Encode(source)).To(Succeed())
+	Expect(gob.NewDecoder(&buffer).Decode(target)).To(Succeed())
+}
+
+var _ = Describe("Encoding", func() {
+	Describe("value fields", func() {
+		type payload struct {
+			Set        ds.Set[string]        `json:"set"`
+			List       ds.List[int]          `json:"list"`
+			AnyList    ds.AnyList[int]       `json:"anyList"`
+			Stack      ds.Stack[int]         `json:"stack"`
+			Queue      ds.Queue[int]         `json:"queue"`
+			Deque      ds.Deque[int]         `json:"deque"`
+			LinkedList ds.LinkedList[string] `json:"linkedList"`
+		}
+
+		const emptyJSON = `{"set":[],"list":[],"anyList":[],"stack":[],"queue":[],"deque":[],"linkedList":[]}`
+
+		It("marshals zero values to empty arrays", func() {
+			var source payload
+			data, err := json.Marshal(&source)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(emptyJSON))
+		})
+
+		It("round-trips zero values through JSON", func() {
+			var target payload
+			Expect(json.Unmarshal([]byte(emptyJSON), &target)).To(Succeed())
+			Expect(target.Set.IsEmpty()).To(BeTrue())
+			Expect(target.List.IsEmpty()).To(BeTrue())
+			Expect(target.Queue.IsEmpty()).To(BeTrue())
+			Expect(target.LinkedList.IsEmpty()).To(BeTrue())
+			target.Queue.Push(1)
+			target.LinkedList.PushBack("a")
+			Expect(target.LinkedList.Items()).To(Equal([]string{"a"}))
+		})
+
+		It("unmarshals null into zero values", func() {
+			var target payload
+			data := `{"set":null,"list":null,"anyList":null,"stack":null,"queue":null,"deque":null,"linkedList":null}`
+			Expect(json.Unmarshal([]byte(data), &target)).To(Succeed())
+			Expect(target.Set.IsEmpty()).To(BeTrue())
+			Expect(target.Deque.IsEmpty()).To(BeTrue())
+			Expect(target.LinkedList.IsEmpty()).To(BeTrue())
+		})
+
+		It("round-trips zero values through gob", func() {
+			var source, target payload
+			gobRoundTrip(&source, &target)
+			Expect(target.Set.IsEmpty()).To(BeTrue())
+			Expect(target.Queue.IsEmpty()).To(BeTrue())
+			Expect(target.LinkedList.IsEmpty()).To(BeTrue())
+		})
+
+		It("round-trips populated values through gob", func() {
+			var source, target payload
+			source.Set.UnmarshalJSON([]byte(`["a"]`))
+			source.Queue.UnmarshalJSON([]byte(`[1,2]`))
+			source.LinkedList.PushBack("x")
+			source.Stack.Push(5)
+			gobRoundTrip(&source, &target)
+			Expect(target.Set.Items()).To(ConsistOf("a"))
+			Expect(target.Queue.Pop()).To(Equal(1))
+			Expect(target.LinkedList.Items()).To(Equal([]string{"x"}))
+			Expect(target.Stack.Peek()).To(Equal(5))
+		})
+	})
+
+	Describe("value fields of other types", func() {
+		type payload struct {
+			Counter    ds.Counter[string]         `json:"counter"`
+			MultiMap   ds.MultiMap[string, int]   `json:"multiMap"`
+			BiMap      ds.BiMap[string, int]      `json:"biMap"`
+			OrderedMap ds.OrderedMap[string, int] `json:"orderedMap"`
+			Trie       ds.Trie[int]               `json:"trie"`
+			SparseSet  ds.SparseSet               `json:"sparseSet"`
+			SparseMap  ds.SparseMap[int]          `json:"sparseMap"`
+			SlotMap    ds.SlotMap[int]            `json:"slotMap"`
+			UnionFind  ds.UnionFind[int]          `json:"unionFind"`
+			BitSet     ds.BitSet                  `json:"bitSet"`
+		}
+
+		It("round-trips zero values through JSON", func() {
+			var source, target payload
+			data, err := json.Marshal(&source)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"counter":[],"multiMap":[],"biMap":[],"orderedMap":{},"trie":{},` +
+				`"sparseSet":{"universe":0,"items":[]},"sparseMap":{"universe":0,"entries":[]},` +
+				`"slotMap":{"generations":[],"owners":[],"values":[]},` +
+				`"unionFind":[],"bitSet":[]}`))
+			Expect(json.Unmarshal(data, &target)).To(Succeed())
+			target.Counter.Add("a", 1)
+			target.MultiMap.Put("a", 1)
+			Expect(target.BiMap.Put("a", 1)).To(Succeed())
+			target.Trie.Put("a", 1)
+			target.SlotMap.Insert(1)
+			target.UnionFind.Add(1)
+			Expect(target.Counter.Total()).To(Equal(1))
+			Expect(target.Trie.Has("a")).To(BeTrue())
+		})
+
+		It("round-trips zero values through gob", func() {
+			var source, target payload
+			gobRoundTrip(&source, &target)
+			Expect(target.Counter.IsEmpty()).To(BeTrue())
+			Expect(target.Trie.IsEmpty()).To(BeTrue())
+		})
+	})
+
+	Describe("nested in a struct", func() {
+		type payload struct {
+			Tags    *ds.Set[string] `json:"tags"`
+			History *ds.List[int]   `json:"history"`
+			Pending *ds.Queue[int]  `json:"pending,omitempty"`
+			Missing *ds.Stack[int]  `json:"missing"`
+			Order   *ds.Deque[int]  `json:"order"`
+		}
+
+		It("round-trips through JSON with nil fields", func() {
+			source := payload{
+				Tags:    ds.SetFromSlice([]string{"b", "a"}),
+				History: ds.ListFromSlice([]int{3, 1}),
+				Order:   ds.NewDeque[int](0),
+			}
+			data, err := json.Marshal(source)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"tags":["a","b"],"history":[3,1],"missing":null,"order":[]}`))
+
+			var target payload
+			Expect(json.Unmarshal(data, &target)).To(Succeed())
+			Expect(target.Tags.Items()).To(ConsistOf("a", "b"))
+			Expect(target.History.Items()).To(Equal([]int{3, 1}))
+			Expect(target.Pending).To(BeNil())
+			Expect(target.Missing).To(BeNil())
+			Expect(target.Order.IsEmpty()).To(BeTrue())
+		})
+
+		It("round-trips through gob with empty collections", func() {
+			source := payload{
+				Tags:    ds.NewSet[string](0),
+				History: ds.ListFromSlice([]int{1}),
+				Pending: ds.NewQueue[int](0),
+			}
+			var target payload
+			gobRoundTrip(source, &target)
+			Expect(target.Tags.IsEmpty()).To(BeTrue())
+			Expect(target.History.Items()).To(Equal([]int{1}))
+			Expect(target.Pending.IsEmpty()).To(BeTrue())
+			Expect(target.Missing).To(BeNil())
+		})
+	})
+})
@@ -2,11 +2,116 @@ package ds_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/mokiat/gog/ds"
+	"github.com/mokiat/gog/seq"
 )
 
+func ExampleAnyList() {
+	type task struct {
+		Name     string
+		Priority int
+		Run      func()
+	}
+
+	tasks := ds.NewAnyList[task](0)
+	tasks.AddAll(
+		task{Name: "cleanup", Priority: 1},
+		task{Name: "render", Priority: 3},
+	)
+	tasks.Insert(1, task{Name: "update", Priority: 2})
+	tasks.SortFunc(func(a, b task) int {
+		return b.Priority - a.Priority
+	})
+
+	tasks.Each(func(item task) {
+		fmt.Println(item.Name)
+	})
+
+	// Output:
+	// render
+	// update
+	// cleanup
+}
+
+func ExampleBiMap() {
+	codes := ds.NewBiMap[string, int](0, ds.ConflictReject)
+	codes.Put("ok", 200)
+	codes.Put("not found", 404)
+
+	fmt.Println(codes.GetByKey("ok"))
+	fmt.Println(codes.Inverse().GetByKey(404))
+	fmt.Println(codes.Put("missing", 404))
+
+	// Output:
+	// 200 true
+	// not found true
+	// value already mapped to another key
+}
+
+func ExampleBitSet() {
+	flags := ds.NewBitSet(128)
+	flags.Set(3)
+	flags.Set(70)
+	flags.Set(12)
+	flags.Flip(3)
+
+	fmt.Println(flags.Count())
+	fmt.Println(flags.Items())
+
+	// Output:
+	// 2
+	// [12 70]
+}
+
+func ExampleCounter() {
+	words := ds.CounterFromSlice([]string{"go", "is", "fun", "go", "go", "fun"})
+	for _, entry := range words.MostCommon(2) {
+		fmt.Println(entry.Item, entry.Count)
+	}
+
+	// Output:
+	// go 3
+	// fun 2
+}
+
+func ExampleDeque() {
+	deque := ds.NewDeque[string](3)
+	deque.PushBack("middle")
+	deque.PushFront("front")
+	deque.PushBack("back")
+	fmt.Println(deque.PopFront())
+	fmt.Println(deque.PopBack())
+	fmt.Println(deque.PopBack())
+
+	// Output:
+	// front
+	// back
+	// middle
+}
+
+func ExampleGraph() {
+	steps := ds.NewGraph[string, struct{}](0)
+	steps.AddEdge("fetch", "compile", struct{}{})
+	steps.AddEdge("compile", "test", struct{}{})
+	steps.AddEdge("compile", "package", struct{}{})
+	steps.AddEdge("test", "package", struct{}{})
+
+	order, err := steps.TopologicalSort()
+	fmt.Println(order, err)
+
+	steps.AddEdge("package", "fetch", struct{}{})
+	_, err = steps.TopologicalSort()
+	fmt.Println(err)
+
+	// Output:
+	// [fetch compile test package] <nil>
+	// graph contains a cycle: fetch -> compile -> package -> fetch
+}
+
 func ExampleHeap() {
 	heap := ds.NewHeap(0, func(a, b int) bool {
 		return a < b
@@ -31,6 +136,80 @@ func ExampleHeap() {
 	// 300
 }
 
+func ExampleHeapFromSlice() {
+	heap := ds.HeapFromSlice([]int{100, 20, 50, 13, 300}, func(a, b int) bool {
+		return a > b
+	})
+	for value := range heap.Drain() {
+		fmt.Println(value)
+	}
+
+	// Output:
+	// 300
+	// 100
+	// 50
+	// 20
+	// 13
+}
+
+func ExampleIndexedHeap() {
+	heap := ds.NewIndexedHeap(0, func(a, b int) bool {
+		return a < b
+	})
+	heap.Push(100)
+	handle := heap.Push(200)
+	heap.Push(50)
+	heap.Update(handle, 10)
+
+	fmt.Println(heap.Pop())
+	fmt.Println(heap.Pop())
+	fmt.Println(heap.Pop())
+
+	// Output:
+	// 10
+	// 50
+	// 100
+}
+
+func ExampleLRU() {
+	cache := ds.NewLRU[string, int](2)
+	cache.OnEvict(func(key string, value int) {
+		fmt.Println("evicted", key)
+	})
+	cache.Put("first", 1)
+	cache.Put("second", 2)
+	cache.Get("first")
+	cache.Put("third", 3)
+
+	for key, value := range cache.All() {
+		fmt.Println(key, value)
+	}
+
+	// Output:
+	// evicted second
+	// third 3
+	// first 1
+}
+
+func ExampleLinkedList() {
+	list := ds.NewLinkedList[string]()
+	list.PushBack("b")
+	last := list.PushBack("d")
+	list.PushFront("a")
+	list.InsertBefore("c", last)
+	list.MoveToFront(last)
+
+	for item := range list.All() {
+		fmt.Println(item)
+	}
+
+	// Output:
+	// d
+	// a
+	// b
+	// c
+}
+
 func ExampleList() {
 	list := ds.NewList[string](0)
 	list.Add("first")
@@ -45,6 +224,70 @@ func ExampleList() {
 	// []string{"first", "fourth"}
 }
 
+func ExampleListFromSeq() {
+	list := ds.ListFromSeq(seq.Times(3))
+	list.Add(10)
+
+	for index, item := range list.Indexed() {
+		fmt.Println(index, item)
+	}
+
+	// Output:
+	// 0 0
+	// 1 1
+	// 2 2
+	// 3 10
+}
+
+func ExampleMultiMap() {
+	tags := ds.NewSetMultiMap[string, string](0)
+	tags.Put("photo.jpg", "holiday")
+	tags.Put("photo.jpg", "beach")
+	tags.Put("photo.jpg", "holiday")
+	tags.Put("notes.txt", "work")
+
+	fmt.Println(tags.Get("photo.jpg"))
+	fmt.Println(tags.Size())
+
+	// Output:
+	// [holiday beach]
+	// 3
+}
+
+func ExampleNewPoolWith() {
+	pool := ds.NewPoolWith(ds.PoolOptions[bytes.Buffer]{
+		Reset: func(buffer *bytes.Buffer) {
+			buffer.Reset()
+		},
+		MaxSize: 16,
+	})
+
+	buffer := pool.Fetch()
+	buffer.WriteString("some data")
+	pool.Restore(buffer)
+
+	newBuffer := pool.Fetch()
+	fmt.Println(newBuffer.Len())
+	fmt.Printf("%+v\n", pool.Stats())
+
+	// Output:
+	// 0
+	// {Hits:1 Misses:1 Drops:0}
+}
+
+func ExampleOrderedMap() {
+	config := ds.NewOrderedMap[string, int](0)
+	config.Set("width", 1920)
+	config.Set("height", 1080)
+	config.Set("depth", 24)
+
+	data, _ := json.Marshal(config)
+	fmt.Println(string(data))
+
+	// Output:
+	// {"width":1920,"height":1080,"depth":24}
+}
+
 func ExamplePool() {
 	pool := ds.NewPool[bytes.Buffer]()
 
@@ -61,6 +304,36 @@ func ExamplePool() {
 	// this buffer is mine
 }
 
+func ExampleQueue() {
+	queue := ds.NewQueue[string](3)
+	queue.Push("first")
+	queue.Push("second")
+	queue.Push("third")
+	fmt.Println(queue.Pop())
+	fmt.Println(queue.Pop())
+	fmt.Println(queue.Pop())
+
+	// Output:
+	// first
+	// second
+	// third
+}
+
+func ExampleRingBuffer() {
+	history := ds.NewRingBuffer[int](3)
+	for _, frameTime := range []int{16, 17, 15, 33, 16} {
+		history.Push(frameTime)
+	}
+	for frameTime := range history.All() {
+		fmt.Println(frameTime)
+	}
+
+	// Output:
+	// 15
+	// 33
+	// 16
+}
+
 func ExampleSet() {
 	set := ds.NewSet[int](0)
 	set.Add(2)
@@ -79,6 +352,87 @@ func ExampleSet() {
 	// false
 }
 
+func ExampleSlotMap() {
+	entities := ds.NewSlotMap[string](0)
+	player := entities.Insert("player")
+	enemy := entities.Insert("enemy")
+
+	entities.Remove(enemy)
+	entities.Insert("projectile")
+
+	_, ok := entities.Get(enemy)
+	fmt.Println(ok)
+	name, ok := entities.Get(player)
+	fmt.Println(*name, ok)
+
+	// Output:
+	// false
+	// player true
+}
+
+func ExampleSortedMap() {
+	scores := ds.NewSortedMap[string, int]()
+	scores.Set("charlie", 70)
+	scores.Set("alice", 90)
+	scores.Set("bob", 80)
+	for name, score := range scores.All() {
+		fmt.Println(name, score)
+	}
+
+	// Output:
+	// alice 90
+	// bob 80
+	// charlie 70
+}
+
+func ExampleSortedSet() {
+	set := ds.SortedSetFromSlice([]int{40, 10, 30, 20})
+	fmt.Println(set.Items())
+	fmt.Println(set.Floor(25))
+	fmt.Println(set.Ceiling(25))
+	fmt.Println(slices.Collect(set.Range(15, 35)))
+
+	// Output:
+	// [10 20 30 40]
+	// 20 true
+	// 30 true
+	// [20 30]
+}
+
+func ExampleSparseMap() {
+	positions := ds.NewSparseMap[float64](1024)
+	positions.Set(7, 1.5)
+	positions.Set(3, 2.5)
+
+	if position, ok := positions.GetPtr(7); ok {
+		*position += 1.0
+	}
+
+	for entity, position := range positions.All() {
+		fmt.Println(entity, position)
+	}
+
+	// Output:
+	// 7 2.5
+	// 3 2.5
+}
+
+func ExampleSparseSet() {
+	set := ds.NewSparseSet(1024)
+	set.Add(512)
+	set.Add(3)
+	set.Add(100)
+	set.Remove(512)
+
+	for item := range set.All() {
+		fmt.Println(item)
+	}
+
+	// Output:
+	// 100
+	// 3
+}
+
 func ExampleStack() {
 	stack := ds.NewStack[string](3)
 	stack.Push("first")
@@ -93,3 +447,48 @@ func ExampleStack() {
 	// second
 	// first
 }
+
+func ExampleTopK() {
+	topK := ds.NewTopK(3, func(a, b int) bool {
+		return a > b
+	})
+	for _, score := range []int{40, 95, 12, 78, 60, 99, 5} {
+		topK.Push(score)
+	}
+	fmt.Println(topK.Items())
+
+	// Output:
+	// [99 95 78]
+}
+
+func ExampleTrie() {
+	commands := ds.NewTrie[string]()
+	commands.Put("status", "show the working tree status")
+	commands.Put("stash", "stash away changes")
+	commands.Put("switch", "switch branches")
+	commands.Put("commit", "record changes")
+
+	for name := range commands.WithPrefix("st") {
+		fmt.Println(name)
+	}
+
+	// Output:
+	// stash
+	// status
+}
+
+func ExampleUnionFind() {
+	groups := ds.NewUnionFind[string](0)
+	groups.Union("alice", "bob")
+	groups.Union("carol", "dave")
+	groups.Union("bob", "eve")
+
+	fmt.Println(groups.Connected("alice", "eve"))
+	fmt.Println(groups.Connected("alice", "carol"))
+	fmt.Println(groups.GroupCount())
+
+	// Output:
+	// true
+	// false
+	// 2
+}
@@ -0,0 +1,497 @@
+package ds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// CycleError is returned when an operation that requires an acyclic graph
+// encounters a cycle.
+type CycleError[N comparable] struct {
+	// Cycle holds the nodes that form the cycle, in edge order. The last node
+	// has an edge to the first one.
+	Cycle []N
+}
+
+// Error returns a description of the cycle.
+func (e *CycleError[N]) Error() string {
+	var builder strings.Builder
+	builder.WriteString("graph contains a cycle: ")
+	for _, node := range e.Cycle {
+		fmt.Fprintf(&builder, "%v -> ", node)
+	}
+	if len(e.Cycle) > 0 {
+		fmt.Fprintf(&builder, "%v", e.Cycle[0])
+	}
+	return builder.String()
+}
+
+// NewGraph creates a new Graph instance with the specified initial capacity
+// for nodes, which is only used to preallocate memory and does not act as
+// an upper bound.
+func NewGraph[N comparable, E any](initialCapacity int) *Graph[N, E] {
+	return &Graph[N, E]{
+		nodes: NewOrderedMap[N, *graphNode[N, E]](initialCapacity),
+	}
+}
+
+// Graph represents a directed graph, where each edge can hold a value of
+// type E. Use struct{} for E if edges do not need to hold any data.
+//
+// Nodes and edges are iterated in the order in which they were added, which
+// makes all algorithms in this type deterministic.
+type Graph[N comparable, E any] struct {
+	nodes     *OrderedMap[N, *graphNode[N, E]]
+	edgeCount int
+}
+
+type graphNode[N comparable, E any] struct {
+	successors   *OrderedMap[N, E]
+	predecessors *OrderedMap[N, E]
+}
+
+// NodeCount returns the number of nodes in this Graph.
+func (g *Graph[N, E]) NodeCount() int {
+	return g.nodes.Size()
+}
+
+// EdgeCount returns the number of edges in this Graph.
+func (g *Graph[N, E]) EdgeCount() int {
+	return g.edgeCount
+}
+
+// IsEmpty returns true if there are no nodes in this Graph.
+func (g *Graph[N, E]) IsEmpty() bool {
+	return g.nodes.IsEmpty()
+}
+
+// AddNode adds the specified node to this Graph. This method returns true
+// if the node was added and false if it was already present.
+func (g *Graph[N, E]) AddNode(node N) bool {
+	if g.nodes.Has(node) {
+		return false
+	}
+	g.addNode(node)
+	return true
+}
+
+// RemoveNode removes the specified node and all of its edges from this
+// Graph. This method returns true if there was such a node and false
+// otherwise.
+func (g *Graph[N, E]) RemoveNode(node N) bool {
+	entry, ok := g.nodes.Get(node)
+	if !ok {
+		return false
+	}
+	for successor := range entry.successors.Keys() {
+		target, _ := g.nodes.Get(successor)
+		target.predecessors.Delete(node)
+		g.edgeCount--
+	}
+	for predecessor := range entry.predecessors.Keys() {
+		source, _ := g.nodes.Get(predecessor)
+		source.successors.Delete(node)
+		g.edgeCount--
+	}
+	g.nodes.Delete(node)
+	return true
+}
+
+// HasNode returns whether this Graph contains the specified node.
+func (g *Graph[N, E]) HasNode(node N) bool {
+	return g.nodes.Has(node)
+}
+
+// Nodes returns a sequence over all nodes in this Graph.
+func (g *Graph[N, E]) Nodes() iter.Seq[N] {
+	return g.nodes.Keys()
+}
+
+// AddEdge adds an edge with the specified value from one node to another.
+// Nodes that are not present are added first. If the edge already exists,
+// its value is replaced.
+//
+// This method returns true if a new edge was added and false if an existing
+// edge was updated.
+func (g *Graph[N, E]) AddEdge(from, to N, value E) bool {
+	source := g.nodeOf(from)
+	target := g.nodeOf(to)
+	added := !source.successors.Has(to)
+	source.successors.Set(to, value)
+	target.predecessors.Set(from, value)
+	if added {
+		g.edgeCount++
+	}
+	return added
+}
+
+// RemoveEdge removes the edge between the specified nodes. This method
+// returns true if there was such an edge and false otherwise.
+func (g *Graph[N, E]) RemoveEdge(from, to N) bool {
+	source, ok := g.nodes.Get(from)
+	if !ok || !source.successors.Delete(to) {
+		return false
+	}
+	target, _ := g.nodes.Get(to)
+	target.predecessors.Delete(from)
+	g.edgeCount--
+	return true
+}
+
+// HasEdge returns whether there is an edge between the specified nodes.
+func (g *Graph[N, E]) HasEdge(from, to N) bool {
+	source, ok := g.nodes.Get(from)
+	return ok && source.successors.Has(to)
+}
+
+// Edge returns the value of the edge between the specified nodes. The
+// second return value is false if there is no such edge.
+func (g *Graph[N, E]) Edge(from, to N) (E, bool) {
+	source, ok := g.nodes.Get(from)
+	if !ok {
+		var zero E
+		return zero, false
+	}
+	return source.successors.Get(to)
+}
+
+// Successors returns a sequence over the nodes that the specified node has
+// edges to, together with the values of those edges.
+func (g *Graph[N, E]) Successors(node N) iter.Seq2[N, E] {
+	return func(yield func(N, E) bool) {
+		if entry, ok := g.nodes.Get(node); ok {
+			for successor, value := range entry.successors.All() {
+				if !yield(successor, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Predecessors returns a sequence over the nodes that have edges to the
+// specified node, together with the values of those edges.
+func (g *Graph[N, E]) Predecessors(node N) iter.Seq2[N, E] {
+	return func(yield func(N, E) bool) {
+		if entry, ok := g.nodes.Get(node); ok {
+			for predecessor, value := range entry.predecessors.All() {
+				if !yield(predecessor, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// BFS returns a sequence that visits all nodes reachable from the specified
+// start node in breadth-first order, starting with the start node itself.
+func (g *Graph[N, E]) BFS(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if !g.nodes.Has(start) {
+			return
+		}
+		visited := NewSet[N](g.NodeCount())
+		queue := NewQueue[N](g.NodeCount())
+		visited.Add(start)
+		queue.Push(start)
+		for !queue.IsEmpty() {
+			node := queue.Pop()
+			if !yield(node) {
+				return
+			}
+			entry, _ := g.nodes.Get(node)
+			for successor := range entry.successors.Keys() {
+				if visited.Add(successor) {
+					queue.Push(successor)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns a sequence that visits all nodes reachable from the specified
+// start node in depth-first pre-order, starting with the start node itself.
+func (g *Graph[N, E]) DFS(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if !g.nodes.Has(start) {
+			return
+		}
+		visited := NewSet[N](g.NodeCount())
+		stack := NewStack[N](g.NodeCount())
+		stack.Push(start)
+		var successors []N
+		for !stack.IsEmpty() {
+			node := stack.Pop()
+			if !visited.Add(node) {
+				continue
+			}
+			if !yield(node) {
+				return
+			}
+			entry, _ := g.nodes.Get(node)
+			successors = slices.AppendSeq(successors[:0], entry.successors.Keys())
+			for _, successor := range slices.Backward(successors) {
+				if !visited.Contains(successor) {
+					stack.Push(successor)
+				}
+			}
+		}
+	}
+}
+
+// TopologicalSort returns the nodes of this Graph ordered such that for
+// every edge, the source node comes before the target node.
+//
+// If the Graph contains a cycle, a *CycleError is returned that names the
+// nodes of one such cycle.
+func (g *Graph[N, E]) TopologicalSort() ([]N, error) {
+	inDegrees := make(map[N]int, g.NodeCount())
+	queue := NewQueue[N](g.NodeCount())
+	for node, entry := range g.nodes.All() {
+		inDegrees[node] = entry.predecessors.Size()
+		if inDegrees[node] == 0 {
+			queue.Push(node)
+		}
+	}
+	result := make([]N, 0, g.NodeCount())
+	for !queue.IsEmpty() {
+		node := queue.Pop()
+		result = append(result, node)
+		delete(inDegrees, node)
+		entry, _ := g.nodes.Get(node)
+		for successor := range entry.successors.Keys() {
+			inDegrees[successor]--
+			if inDegrees[successor] == 0 {
+				queue.Push(successor)
+			}
+		}
+	}
+	if len(inDegrees) > 0 {
+		return nil, &CycleError[N]{
+			Cycle: g.findCycle(inDegrees),
+		}
+	}
+	return result, nil
+}
+
+// StronglyConnectedComponents returns the strongly connected components of
+// this Graph. Each component is a group of nodes where every node is
+// reachable from every other node in the group.
+//
+// The components are returned in reverse topological order, meaning that
+// there are no edges from a component to one that comes after it. The order
+// of the nodes within a component is unspecified.
+func (g *Graph[N, E]) StronglyConnectedComponents() [][]N {
+	state := &tarjanState[N, E]{
+		graph:   g,
+		indices: make(map[N]int, g.NodeCount()),
+		lowLink: make(map[N]int, g.NodeCount()),
+		onStack: NewSet[N](g.NodeCount()),
+		stack:   NewStack[N](g.NodeCount()),
+	}
+	for node := range g.nodes.Keys() {
+		if _, ok := state.indices[node]; !ok {
+			state.visit(node)
+		}
+	}
+	return state.components
+}
+
+// Clear removes all nodes and edges from this Graph.
+func (g *Graph[N, E]) Clear() {
+	g.nodes.Clear()
+	g.edgeCount = 0
+}
+
+// MarshalJSON encodes this Graph as a JSON object that holds the nodes in
+// the order in which they were added, followed by the edges of each node in
+// the same order.
+func (g *Graph[N, E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.encoded())
+}
+
+// UnmarshalJSON decodes a JSON object that was produced by MarshalJSON into
+// this Graph. Existing nodes and edges are removed beforehand.
+//
+// The nodes and the successors of each node keep their order. The
+// predecessors of each node follow the order of the nodes, which can differ
+// from the order in which the edges were originally added.
+func (g *Graph[N, E]) UnmarshalJSON(data []byte) error {
+	var encoded graphEncoding[N, E]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return g.replace(encoded)
+}
+
+// MarshalBinary encodes this Graph using encoding/gob.
+func (g *Graph[N, E]) MarshalBinary() ([]byte, error) {
+	return marshalGob(g.encoded())
+}
+
+// UnmarshalBinary decodes a Graph that was encoded with MarshalBinary.
+// Existing nodes and edges are removed beforehand.
+func (g *Graph[N, E]) UnmarshalBinary(data []byte) error {
+	var encoded graphEncoding[N, E]
+	if err := unmarshalGob(data, &encoded); err != nil {
+		return err
+	}
+	return g.replace(encoded)
+}
+
+func (g *Graph[N, E]) encoded() graphEncoding[N, E] {
+	result := graphEncoding[N, E]{
+		Nodes: []N{},
+		Edges: []graphEdge[N, E]{},
+	}
+	if g.nodes == nil {
+		return result
+	}
+	for node, entry := range g.nodes.All() {
+		result.Nodes = append(result.Nodes, node)
+		for successor, value := range entry.successors.All() {
+			result.Edges = append(result.Edges, graphEdge[N, E]{
+				From:  node,
+				To:    successor,
+				Value: value,
+			})
+		}
+	}
+	return result
+}
+
+func (g *Graph[N, E]) replace(encoded graphEncoding[N, E]) error {
+	if g.nodes == nil {
+		g.nodes = NewOrderedMap[N, *graphNode[N, E]](len(encoded.Nodes))
+	}
+	g.Clear()
+	for _, node := range encoded.Nodes {
+		if !g.AddNode(node) {
+			return fmt.Errorf("graph contains duplicate node %v", node)
+		}
+	}
+	for _, edge := range encoded.Edges {
+		if !g.HasNode(edge.From) || !g.HasNode(edge.To) {
+			return errors.New("graph edge references an unknown node")
+		}
+		if !g.AddEdge(edge.From, edge.To, edge.Value) {
+			return fmt.Errorf("graph contains duplicate edge %v -> %v", edge.From, edge.To)
+		}
+	}
+	return nil
+}
+
+type graphEncoding[N comparable, E any] struct {
+	Nodes []N               `json:"nodes"`
+	Edges []graphEdge[N, E] `json:"edges"`
+}
+
+type graphEdge[N comparable, E any] struct {
+	From  N `json:"from"`
+	To    N `json:"to"`
+	Value E `json:"value"`
+}
+
+func (g *Graph[N, E]) nodeOf(node N) *graphNode[N, E] {
+	if entry, ok := g.nodes.Get(node); ok {
+		return entry
+	}
+	return g.addNode(node)
+}
+
+func (g *Graph[N, E]) addNode(node N) *graphNode[N, E] {
+	entry := &graphNode[N, E]{
+		successors:   NewOrderedMap[N, E](0),
+		predecessors: NewOrderedMap[N, E](0),
+	}
+	g.nodes.Set(node, entry)
+	return entry
+}
+
+// findCycle finds a cycle among the specified remaining nodes, each of which
+// is guaranteed to have a predecessor that is also remaining.
+func (g *Graph[N, E]) findCycle(remaining map[N]int) []N {
+	var node N
+	for candidate := range g.nodes.Keys() {
+		if _, ok := remaining[candidate]; ok {
+			node = candidate
+			break
+		}
+	}
+	positions := make(map[N]int)
+	var path []N
+	for {
+		if position, ok := positions[node]; ok {
+			cycle := path[position:]
+			slices.Reverse(cycle)
+			return g.rotateCycle(cycle)
+		}
+		positions[node] = len(path)
+		path = append(path, node)
+		entry, _ := g.nodes.Get(node)
+		for predecessor := range entry.predecessors.Keys() {
+			if _, ok := remaining[predecessor]; ok {
+				node = predecessor
+				break
+			}
+		}
+	}
+}
+
+// rotateCycle rotates the specified cycle so that it starts with the node
+// that was added to the graph first.
+func (g *Graph[N, E]) rotateCycle(cycle []N) []N {
+	members := SetFromSlice(cycle)
+	for node := range g.nodes.Keys() {
+		if members.Contains(node) {
+			start := slices.Index(cycle, node)
+			return append(cycle[start:], cycle[:start]...)
+		}
+	}
+	return cycle
+}
+
+type tarjanState[N comparable, E any] struct {
+	graph      *Graph[N, E]
+	index      int
+	indices    map[N]int
+	lowLink    map[N]int
+	onStack    *Set[N]
+	stack      *Stack[N]
+	components [][]N
+}
+
+func (s *tarjanState[N, E]) visit(node N) {
+	s.indices[node] = s.index
+	s.lowLink[node] = s.index
+	s.index++
+	s.stack.Push(node)
+	s.onStack.Add(node)
+
+	entry, _ := s.graph.nodes.Get(node)
+	for successor := range entry.successors.Keys() {
+		if _, ok := s.indices[successor]; !ok {
+			s.visit(successor)
+			s.lowLink[node] = min(s.lowLink[node], s.lowLink[successor])
+		} else if s.onStack.Contains(successor) {
+			s.lowLink[node] = min(s.lowLink[node], s.indices[successor])
+		}
+	}
+
+	if s.lowLink[node] == s.indices[node] {
+		var component []N
+		for {
+			member := s.stack.Pop()
+			s.onStack.Remove(member)
+			component = append(component, member)
+			if member == node {
+				break
+			}
+		}
+		s.components = append(s.components, component)
+	}
+}
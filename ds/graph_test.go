@@ -0,0 +1,210 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("Graph", func() {
+	var graph *ds.Graph[string, int]
+
+	BeforeEach(func() {
+		graph = ds.NewGraph[string, int](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(graph.IsEmpty()).To(BeTrue())
+		Expect(graph.NodeCount()).To(BeZero())
+		Expect(graph.EdgeCount()).To(BeZero())
+	})
+
+	It("is possible to add nodes", func() {
+		Expect(graph.AddNode("a")).To(BeTrue())
+		Expect(graph.AddNode("a")).To(BeFalse())
+		Expect(graph.HasNode("a")).To(BeTrue())
+		Expect(graph.NodeCount()).To(Equal(1))
+	})
+
+	When("edges are added", func() {
+		BeforeEach(func() {
+			// a -> b -> d
+			// a -> c -> d -> e
+			Expect(graph.AddEdge("a", "b", 1)).To(BeTrue())
+			Expect(graph.AddEdge("a", "c", 2)).To(BeTrue())
+			Expect(graph.AddEdge("b", "d", 3)).To(BeTrue())
+			Expect(graph.AddEdge("c", "d", 4)).To(BeTrue())
+			Expect(graph.AddEdge("d", "e", 5)).To(BeTrue())
+		})
+
+		It("adds the missing nodes", func() {
+			Expect(graph.NodeCount()).To(Equal(5))
+			Expect(slices.Collect(graph.Nodes())).To(Equal([]string{"a", "b", "c", "d", "e"}))
+		})
+
+		It("has the correct edge count", func() {
+			Expect(graph.EdgeCount()).To(Equal(5))
+		})
+
+		It("is possible to query edges", func() {
+			Expect(graph.HasEdge("a", "b")).To(BeTrue())
+			Expect(graph.HasEdge("b", "a")).To(BeFalse())
+			value, ok := graph.Edge("c", "d")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(4))
+		})
+
+		It("replaces the value of existing edges", func() {
+			Expect(graph.AddEdge("a", "b", 10)).To(BeFalse())
+			Expect(graph.EdgeCount()).To(Equal(5))
+			value, _ := graph.Edge("a", "b")
+			Expect(value).To(Equal(10))
+			Expect(maps.Collect(graph.Predecessors("b"))).To(Equal(map[string]int{"a": 10}))
+		})
+
+		It("iterates successors and predecessors", func() {
+			Expect(maps.Collect(graph.Successors("a"))).To(Equal(map[string]int{"b": 1, "c": 2}))
+			Expect(maps.Collect(graph.Predecessors("d"))).To(Equal(map[string]int{"b": 3, "c": 4}))
+			Expect(maps.Collect(graph.Successors("missing"))).To(BeEmpty())
+		})
+
+		It("is possible to remove edges", func() {
+			Expect(graph.RemoveEdge("a", "b")).To(BeTrue())
+			Expect(graph.RemoveEdge("a", "b")).To(BeFalse())
+			Expect(graph.HasEdge("a", "b")).To(BeFalse())
+			Expect(graph.EdgeCount()).To(Equal(4))
+			Expect(maps.Collect(graph.Predecessors("b"))).To(BeEmpty())
+		})
+
+		It("is possible to remove nodes with their edges", func() {
+			Expect(graph.RemoveNode("d")).To(BeTrue())
+			Expect(graph.RemoveNode("d")).To(BeFalse())
+			Expect(graph.HasNode("d")).To(BeFalse())
+			Expect(graph.EdgeCount()).To(Equal(2))
+			Expect(maps.Collect(graph.Successors("b"))).To(BeEmpty())
+			Expect(maps.Collect(graph.Predecessors("e"))).To(BeEmpty())
+		})
+
+		It("traverses breadth-first", func() {
+			Expect(slices.Collect(graph.BFS("a"))).To(Equal([]string{"a", "b", "c", "d", "e"}))
+			Expect(slices.Collect(graph.BFS("c"))).To(Equal([]string{"c", "d", "e"}))
+			Expect(slices.Collect(graph.BFS("missing"))).To(BeEmpty())
+		})
+
+		It("traverses depth-first", func() {
+			Expect(slices.Collect(graph.DFS("a"))).To(Equal([]string{"a", "b", "d", "e", "c"}))
+			Expect(slices.Collect(graph.DFS("missing"))).To(BeEmpty())
+		})
+
+		It("sorts the nodes topologically", func() {
+			nodes, err := graph.TopologicalSort()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes).To(Equal([]string{"a", "b", "c", "d", "e"}))
+		})
+
+		It("has a component per node", func() {
+			components := graph.StronglyConnectedComponents()
+			Expect(components).To(HaveLen(5))
+		})
+
+		When("a cycle is introduced", func() {
+			BeforeEach(func() {
+				graph.AddEdge("e", "c", 6)
+			})
+
+			It("fails to sort the nodes topologically", func() {
+				_, err := graph.TopologicalSort()
+				Expect(err).To(HaveOccurred())
+
+				var cycleErr *ds.CycleError[string]
+				Expect(errors.As(err, &cycleErr)).To(BeTrue())
+				Expect(cycleErr.Cycle).To(Equal([]string{"c", "d", "e"}))
+				Expect(err.Error()).To(Equal("graph contains a cycle: c -> d -> e -> c"))
+			})
+
+			It("groups the cycle into a component", func() {
+				components := graph.StronglyConnectedComponents()
+				Expect(components).To(HaveLen(3))
+				Expect(components[0]).To(ConsistOf("c", "d", "e"))
+				Expect(components[1]).To(Equal([]string{"b"}))
+				Expect(components[2]).To(Equal([]string{"a"}))
+			})
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				graph.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(graph.IsEmpty()).To(BeTrue())
+				Expect(graph.EdgeCount()).To(BeZero())
+			})
+		})
+	})
+
+	It("detects self-loops as cycles", func() {
+		graph.AddEdge("a", "a", 0)
+		_, err := graph.TopologicalSort()
+		var cycleErr *ds.CycleError[string]
+		Expect(errors.As(err, &cycleErr)).To(BeTrue())
+		Expect(cycleErr.Cycle).To(Equal([]string{"a"}))
+
+		Expect(graph.RemoveNode("a")).To(BeTrue())
+		Expect(graph.EdgeCount()).To(BeZero())
+	})
+
+	Describe("marshaling", func() {
+		BeforeEach(func() {
+			graph.AddNode("c")
+			graph.AddEdge("a", "b", 1)
+			graph.AddEdge("a", "c", 2)
+			graph.AddEdge("c", "a", 3)
+		})
+
+		It("round-trips through JSON", func() {
+			data, err := json.Marshal(graph)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"nodes":["c","a","b"],"edges":[` +
+				`{"from":"c","to":"a","value":3},{"from":"a","to":"b","value":1},{"from":"a","to":"c","value":2}]}`))
+
+			var restored ds.Graph[string, int]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(slices.Collect(restored.Nodes())).To(Equal([]string{"c", "a", "b"}))
+			Expect(restored.EdgeCount()).To(Equal(3))
+			Expect(maps.Collect(restored.Successors("a"))).To(Equal(map[string]int{"b": 1, "c": 2}))
+			Expect(maps.Collect(restored.Predecessors("a"))).To(Equal(map[string]int{"c": 3}))
+			Expect(slices.Collect(restored.BFS("a"))).To(Equal([]string{"a", "b", "c"}))
+		})
+
+		It("marshals a zero value with no nodes", func() {
+			var zero ds.Graph[string, int]
+			data, err := json.Marshal(&zero)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"nodes":[],"edges":[]}`))
+		})
+
+		It("fails to unmarshal inconsistent state", func() {
+			Expect(json.Unmarshal([]byte(`{"nodes":["a","a"],"edges":[]}`), graph)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`{"nodes":["a"],"edges":[{"from":"a","to":"b","value":1}]}`), graph)).ToNot(Succeed())
+			data := `{"nodes":["a","b"],"edges":[{"from":"a","to":"b","value":1},{"from":"a","to":"b","value":2}]}`
+			Expect(json.Unmarshal([]byte(data), graph)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			var restored ds.Graph[string, int]
+			gobRoundTrip(graph, &restored)
+			Expect(slices.Collect(restored.Nodes())).To(Equal([]string{"c", "a", "b"}))
+			value, ok := restored.Edge("c", "a")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(3))
+			Expect(restored.EdgeCount()).To(Equal(3))
+		})
+	})
+})
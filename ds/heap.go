@@ -1,6 +1,12 @@
 package ds
 
-import "slices"
+import (
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/mokiat/gog/internal/binheap"
+)
 
 // NewHeap creates a new Heap instance that is configured to use the
 // specified better function to order items. When better returns true, the
@@ -13,10 +19,25 @@ func NewHeap[T any](initialCapacity int, better func(a, b T) bool) *Heap[T] {
 	}
 }
 
+// HeapFromSlice creates a new Heap instance that contains the items from the
+// specified slice and is ordered according to the specified better function.
+//
+// The heap is built in O(n) time. It is safe to modify the slice afterwards,
+// as the heap creates its own internal copy.
+func HeapFromSlice[T any](items []T, better func(a, b T) bool) *Heap[T] {
+	result := &Heap[T]{
+		better: better,
+		items:  slices.Clone(items),
+	}
+	binheap.Init(result.items, better, nil)
+	return result
+}
+
 // Heap is a data structure that orders items when inserted according to a
 // specified ordering.
 type Heap[T any] struct {
 	better func(a, b T) bool
+	moved  func(value T, index int)
 	items  []T
 }
 
@@ -33,22 +54,15 @@ func (h *Heap[T]) Size() int {
 // Push adds a new item to this Heap.
 func (h *Heap[T]) Push(value T) {
 	h.items = append(h.items, value)
-	h.siftUp(value, len(h.items)-1)
+	h.place(len(h.items)-1, value)
+	binheap.SiftUp(h.items, len(h.items)-1, h.better, h.moved)
 }
 
 // Pop removes the top-most item from this Heap and returns it.
 // This method panics if the Heap is empty so make sure to use IsEmpty
 // beforehand.
 func (h *Heap[T]) Pop() T {
-	result := h.items[0]
-	if len(h.items) > 1 {
-		h.items[0] = h.items[len(h.items)-1]
-		h.items = h.items[:len(h.items)-1]
-		h.siftDown(h.items[0], 0)
-	} else {
-		h.items = h.items[:len(h.items)-1]
-	}
-	return result
+	return h.removeAt(0)
 }
 
 // Peek returns the top-most item from this Heap without removing it.
@@ -58,6 +72,26 @@ func (h *Heap[T]) Peek() T {
 	return h.items[0]
 }
 
+// All returns a sequence over all items in this Heap without removing them.
+//
+// Note: The items are returned in an unspecified order.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return slices.Values(h.items)
+}
+
+// Drain returns a sequence that pops items from this Heap in priority order.
+// Items that have been yielded are removed from the Heap, even if the
+// iteration is stopped early.
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !h.IsEmpty() {
+			if !yield(h.Pop()) {
+				return
+			}
+		}
+	}
+}
+
 // Clear removes all items from this Heap.
 func (h *Heap[T]) Clear() {
 	h.items = h.items[:0]
@@ -68,45 +102,73 @@ func (s *Heap[T]) Clip() {
 	s.items = slices.Clip(s.items)
 }
 
-func (h *Heap[T]) siftUp(value T, index int) {
-	for index > 0 {
-		parentIndex := (index - 1) / 2
-		parentValue := h.items[parentIndex]
-		if !h.better(value, parentValue) {
-			return
-		}
-		h.items[index] = parentValue
-		h.items[parentIndex] = value
-		index = parentIndex
+// MarshalJSON encodes this Heap as a JSON array of its items in an
+// unspecified order.
+func (h *Heap[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(h.items)
+}
+
+// UnmarshalJSON decodes a JSON array into this Heap. Existing items are
+// removed beforehand.
+//
+// The items are ordered with the better function of this Heap, which means
+// that it needs to be created through NewHeap or HeapFromSlice. Decoding
+// into a zero value returns an error.
+func (h *Heap[T]) UnmarshalJSON(data []byte) error {
+	items, err := unmarshalItemsJSON[T](data)
+	if err != nil {
+		return err
 	}
+	return h.replace(items)
 }
 
-func (h *Heap[T]) siftDown(value T, index int) {
-	leftChildIndex := index*2 + 1
-	for leftChildIndex < len(h.items) {
-		bestIndex := index
+// MarshalBinary encodes this Heap using encoding/gob.
+func (h *Heap[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(h.items)
+}
 
-		leftValue := h.items[leftChildIndex]
-		if h.better(leftValue, value) {
-			bestIndex = leftChildIndex
-		}
+// UnmarshalBinary decodes a Heap that was encoded with MarshalBinary.
+// Existing items are removed beforehand. As with UnmarshalJSON, this Heap
+// needs to have a better function.
+func (h *Heap[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalItemsBinary[T](data)
+	if err != nil {
+		return err
+	}
+	return h.replace(items)
+}
 
-		rightChildIndex := leftChildIndex + 1
-		if rightChildIndex < len(h.items) {
-			rightValue := h.items[rightChildIndex]
-			if h.better(rightValue, value) && h.better(rightValue, leftValue) {
-				bestIndex = rightChildIndex
-			}
-		}
+func (h *Heap[T]) replace(items []T) error {
+	if h.better == nil {
+		return errors.New("heap has no better function")
+	}
+	h.items = items
+	binheap.Init(h.items, h.better, h.moved)
+	return nil
+}
 
-		if bestIndex == index {
-			return
-		}
+func (h *Heap[T]) place(index int, value T) {
+	h.items[index] = value
+	if h.moved != nil {
+		h.moved(value, index)
+	}
+}
 
-		h.items[index] = h.items[bestIndex]
-		h.items[bestIndex] = value
+func (h *Heap[T]) fix(index int) {
+	binheap.Fix(h.items, index, h.better, h.moved)
+}
 
-		index = bestIndex
-		leftChildIndex = index*2 + 1
+func (h *Heap[T]) removeAt(index int) T {
+	result := h.items[index]
+	lastIndex := len(h.items) - 1
+	if index != lastIndex {
+		h.place(index, h.items[lastIndex])
 	}
+	var zero T
+	h.items[lastIndex] = zero
+	h.items = h.items[:lastIndex]
+	if index != lastIndex {
+		h.fix(index)
+	}
+	return result
 }
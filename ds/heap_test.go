@@ -1,6 +1,9 @@
 package ds_test
 
 import (
+	"encoding/json"
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -57,6 +60,26 @@ var _ = Describe("Heap", func() {
 			Expect(heap.IsEmpty()).To(BeTrue())
 		})
 
+		It("is possible to visit all items without removing them", func() {
+			Expect(slices.Collect(heap.All())).To(ConsistOf(21, 10, 2, 15, 6, 15))
+			Expect(heap.Size()).To(Equal(6))
+		})
+
+		It("is possible to drain all items in order", func() {
+			Expect(slices.Collect(heap.Drain())).To(Equal([]int{2, 6, 10, 15, 15, 21}))
+			Expect(heap.IsEmpty()).To(BeTrue())
+		})
+
+		It("keeps remaining items when draining is stopped", func() {
+			for value := range heap.Drain() {
+				if value == 10 {
+					break
+				}
+			}
+			Expect(heap.Size()).To(Equal(3))
+			Expect(heap.Pop()).To(Equal(15))
+		})
+
 		When("clipped", func() {
 			BeforeEach(func() {
 				heap.Clip()
@@ -117,4 +140,76 @@ var _ = Describe("Heap", func() {
 			})
 		})
 	})
+
+	Describe("HeapFromSlice", func() {
+		var source []int
+
+		BeforeEach(func() {
+			source = []int{9, 4, 7, 1, 8, 2, 2, 6, 3, 5}
+			heap = ds.HeapFromSlice(source, func(a, b int) bool {
+				return a < b
+			})
+		})
+
+		It("contains all items", func() {
+			Expect(heap.Size()).To(Equal(10))
+		})
+
+		It("is possible to fetch all items in order", func() {
+			Expect(slices.Collect(heap.Drain())).To(Equal([]int{1, 2, 2, 3, 4, 5, 6, 7, 8, 9}))
+		})
+
+		It("does not modify the source slice", func() {
+			Expect(source).To(Equal([]int{9, 4, 7, 1, 8, 2, 2, 6, 3, 5}))
+		})
+
+		It("works with an empty slice", func() {
+			heap = ds.HeapFromSlice(nil, func(a, b int) bool {
+				return a < b
+			})
+			Expect(heap.IsEmpty()).To(BeTrue())
+		})
+	})
+
+	Describe("marshaling", func() {
+		BeforeEach(func() {
+			for _, value := range []int{5, 2, 8, 1, 9} {
+				heap.Push(value)
+			}
+		})
+
+		It("round-trips through JSON", func() {
+			data, err := json.Marshal(heap)
+			Expect(err).ToNot(HaveOccurred())
+
+			restored := ds.NewHeap(0, func(a, b int) bool {
+				return a < b
+			})
+			restored.Push(0)
+			Expect(json.Unmarshal(data, restored)).To(Succeed())
+			Expect(slices.Collect(restored.Drain())).To(Equal([]int{1, 2, 5, 8, 9}))
+		})
+
+		It("orders unmarshaled items with its better function", func() {
+			restored := ds.NewHeap(0, func(a, b int) bool {
+				return a > b
+			})
+			Expect(json.Unmarshal([]byte(`[3,1,4,2]`), restored)).To(Succeed())
+			restored.Push(0)
+			Expect(slices.Collect(restored.Drain())).To(Equal([]int{4, 3, 2, 1, 0}))
+		})
+
+		It("fails to unmarshal into a zero value", func() {
+			var zero ds.Heap[int]
+			Expect(json.Unmarshal([]byte(`[1]`), &zero)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			restored := ds.NewHeap(0, func(a, b int) bool {
+				return a < b
+			})
+			gobRoundTrip(heap, restored)
+			Expect(slices.Collect(restored.Drain())).To(Equal([]int{1, 2, 5, 8, 9}))
+		})
+	})
 })
@@ -0,0 +1,145 @@
+package ds
+
+import "iter"
+
+// NewIndexedHeap creates a new IndexedHeap instance that is configured to use
+// the specified better function to order items. When better returns true, the
+// first argument will be placed higher in the heap.
+// The specified initialCapacity is used to preallocate memory.
+func NewIndexedHeap[T any](initialCapacity int, better func(a, b T) bool) *IndexedHeap[T] {
+	return &IndexedHeap[T]{
+		heap: &Heap[*HeapHandle[T]]{
+			better: func(a, b *HeapHandle[T]) bool {
+				return better(a.value, b.value)
+			},
+			moved: func(handle *HeapHandle[T], index int) {
+				handle.index = index
+			},
+			items: make([]*HeapHandle[T], 0, initialCapacity),
+		},
+	}
+}
+
+// HeapHandle references an item that has been pushed to an IndexedHeap.
+// It can be used to update or remove the item while it is still in the heap.
+type HeapHandle[T any] struct {
+	value T
+	index int
+}
+
+// Value returns the item that this HeapHandle references.
+func (h *HeapHandle[T]) Value() T {
+	return h.value
+}
+
+// IndexedHeap is a Heap that returns a handle for each pushed item, allowing
+// the item to be updated or removed in O(log n) time afterwards. This makes
+// it suitable for algorithms where priorities change, like Dijkstra, A* or
+// timer scheduling.
+type IndexedHeap[T any] struct {
+	heap *Heap[*HeapHandle[T]]
+}
+
+// IsEmpty returns true if there are no items in this IndexedHeap and false
+// otherwise.
+func (h *IndexedHeap[T]) IsEmpty() bool {
+	return h.heap.IsEmpty()
+}
+
+// Size returns the number of items stored in this IndexedHeap.
+func (h *IndexedHeap[T]) Size() int {
+	return h.heap.Size()
+}
+
+// Push adds a new item to this IndexedHeap and returns a handle to it.
+func (h *IndexedHeap[T]) Push(value T) *HeapHandle[T] {
+	handle := &HeapHandle[T]{
+		value: value,
+	}
+	h.heap.Push(handle)
+	return handle
+}
+
+// Pop removes the top-most item from this IndexedHeap and returns it.
+// This method panics if the IndexedHeap is empty so make sure to use IsEmpty
+// beforehand.
+func (h *IndexedHeap[T]) Pop() T {
+	handle := h.heap.Pop()
+	handle.index = -1
+	return handle.value
+}
+
+// Peek returns the top-most item from this IndexedHeap without removing it.
+// This method panics if the IndexedHeap is empty so make sure to use IsEmpty
+// beforehand.
+func (h *IndexedHeap[T]) Peek() T {
+	return h.heap.Peek().value
+}
+
+// Contains returns whether the item referenced by the specified handle is
+// still part of this IndexedHeap.
+func (h *IndexedHeap[T]) Contains(handle *HeapHandle[T]) bool {
+	items := h.heap.items
+	return handle.index >= 0 && handle.index < len(items) && items[handle.index] == handle
+}
+
+// Update changes the item referenced by the specified handle and restores
+// the heap ordering.
+// This method panics if the handle is not part of this IndexedHeap.
+func (h *IndexedHeap[T]) Update(handle *HeapHandle[T], value T) {
+	h.checkContains(handle)
+	handle.value = value
+	h.heap.fix(handle.index)
+}
+
+// Fix restores the heap ordering after the item referenced by the specified
+// handle has been modified in place (e.g. when T is a pointer type).
+// This method panics if the handle is not part of this IndexedHeap.
+func (h *IndexedHeap[T]) Fix(handle *HeapHandle[T]) {
+	h.checkContains(handle)
+	h.heap.fix(handle.index)
+}
+
+// Remove removes the item referenced by the specified handle from this
+// IndexedHeap and returns it.
+// This method panics if the handle is not part of this IndexedHeap.
+func (h *IndexedHeap[T]) Remove(handle *HeapHandle[T]) T {
+	h.checkContains(handle)
+	h.heap.removeAt(handle.index)
+	handle.index = -1
+	return handle.value
+}
+
+// All returns a sequence over all items in this IndexedHeap without
+// removing them.
+//
+// Note: The items are returned in an unspecified order.
+func (h *IndexedHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, handle := range h.heap.items {
+			if !yield(handle.value) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all items from this IndexedHeap.
+func (h *IndexedHeap[T]) Clear() {
+	for _, handle := range h.heap.items {
+		handle.index = -1
+	}
+	clear(h.heap.items)
+	h.heap.Clear()
+}
+
+// Clip removes unused capacity from the IndexedHeap.
+func (h *IndexedHeap[T]) Clip() {
+	h.heap.Clip()
+}
+
+func (h *IndexedHeap[T]) checkContains(handle *HeapHandle[T]) {
+	if !h.Contains(handle) {
+		panic("ds: handle is not part of the heap")
+	}
+}
@@ -0,0 +1,144 @@
+package ds_test
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("IndexedHeap", func() {
+	var heap *ds.IndexedHeap[int]
+
+	BeforeEach(func() {
+		smallerInt := func(a, b int) bool {
+			return a < b
+		}
+		heap = ds.NewIndexedHeap(0, smallerInt)
+	})
+
+	It("is empty by default", func() {
+		Expect(heap.IsEmpty()).To(BeTrue())
+		Expect(heap.Size()).To(BeZero())
+	})
+
+	When("items are added", func() {
+		var handles []*ds.HeapHandle[int]
+
+		BeforeEach(func() {
+			handles = nil
+			for _, value := range []int{21, 10, 2, 15, 6, 15} {
+				handles = append(handles, heap.Push(value))
+			}
+		})
+
+		It("has the correct size", func() {
+			Expect(heap.Size()).To(Equal(6))
+		})
+
+		It("returns handles that reference the items", func() {
+			Expect(handles[0].Value()).To(Equal(21))
+			Expect(handles[2].Value()).To(Equal(2))
+		})
+
+		It("is possible to iterate the items", func() {
+			Expect(slices.Collect(heap.All())).To(ConsistOf(21, 10, 2, 15, 6, 15))
+			Expect(heap.Size()).To(Equal(6))
+		})
+
+		It("contains all handles", func() {
+			for _, handle := range handles {
+				Expect(heap.Contains(handle)).To(BeTrue())
+			}
+		})
+
+		It("is possible to fetch all items in order", func() {
+			Expect(heap.Pop()).To(Equal(2))
+			Expect(heap.Pop()).To(Equal(6))
+			Expect(heap.Pop()).To(Equal(10))
+			Expect(heap.Pop()).To(Equal(15))
+			Expect(heap.Pop()).To(Equal(15))
+			Expect(heap.Pop()).To(Equal(21))
+			Expect(heap.IsEmpty()).To(BeTrue())
+		})
+
+		It("no longer contains popped items", func() {
+			heap.Pop()
+			Expect(heap.Contains(handles[2])).To(BeFalse())
+			Expect(heap.Contains(handles[4])).To(BeTrue())
+		})
+
+		It("is possible to improve an item", func() {
+			heap.Update(handles[0], 1)
+			Expect(heap.Peek()).To(Equal(1))
+			Expect(handles[0].Value()).To(Equal(1))
+		})
+
+		It("is possible to worsen an item", func() {
+			heap.Update(handles[2], 100)
+			Expect(heap.Pop()).To(Equal(6))
+			Expect(heap.Pop()).To(Equal(10))
+			Expect(heap.Pop()).To(Equal(15))
+			Expect(heap.Pop()).To(Equal(15))
+			Expect(heap.Pop()).To(Equal(21))
+			Expect(heap.Pop()).To(Equal(100))
+		})
+
+		It("is possible to remove an item", func() {
+			Expect(heap.Remove(handles[1])).To(Equal(10))
+			Expect(heap.Contains(handles[1])).To(BeFalse())
+			Expect(heap.Size()).To(Equal(5))
+			Expect(heap.Pop()).To(Equal(2))
+			Expect(heap.Pop()).To(Equal(6))
+			Expect(heap.Pop()).To(Equal(15))
+			Expect(heap.Pop()).To(Equal(15))
+			Expect(heap.Pop()).To(Equal(21))
+		})
+
+		It("panics when using a removed handle", func() {
+			heap.Remove(handles[1])
+			Expect(func() { heap.Remove(handles[1]) }).To(Panic())
+			Expect(func() { heap.Update(handles[1], 0) }).To(Panic())
+			Expect(func() { heap.Fix(handles[1]) }).To(Panic())
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				heap.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(heap.IsEmpty()).To(BeTrue())
+			})
+
+			It("no longer contains any handles", func() {
+				for _, handle := range handles {
+					Expect(heap.Contains(handle)).To(BeFalse())
+				}
+			})
+		})
+	})
+
+	When("items are modified in place", func() {
+		type task struct {
+			priority int
+		}
+
+		It("is possible to fix their position", func() {
+			taskHeap := ds.NewIndexedHeap(0, func(a, b *task) bool {
+				return a.priority < b.priority
+			})
+			first := &task{priority: 1}
+			second := &task{priority: 2}
+			taskHeap.Push(first)
+			handle := taskHeap.Push(second)
+
+			second.priority = 0
+			taskHeap.Fix(handle)
+			Expect(taskHeap.Pop()).To(BeIdenticalTo(second))
+			Expect(taskHeap.Pop()).To(BeIdenticalTo(first))
+		})
+	})
+})
@@ -0,0 +1,309 @@
+package ds
+
+import "iter"
+
+// NewLinkedList creates a new empty LinkedList instance.
+func NewLinkedList[T any]() *LinkedList[T] {
+	result := &LinkedList[T]{}
+	result.lazyInit()
+	return result
+}
+
+// LinkedListFromSlice creates a new LinkedList instance that contains the
+// items of the specified slice in the same order.
+func LinkedListFromSlice[T any](slice []T) *LinkedList[T] {
+	result := NewLinkedList[T]()
+	for _, item := range slice {
+		result.PushBack(item)
+	}
+	return result
+}
+
+// LinkedList is a doubly linked list. Inserting an item returns an Element
+// handle that can later be used to insert, remove or move items around it in
+// O(1) time, without requiring the items to be comparable.
+//
+// The zero value of a LinkedList is an empty list that is ready to use.
+type LinkedList[T any] struct {
+	root Element[T]
+	size int
+}
+
+// IsEmpty returns true if there are no items in this LinkedList.
+func (l *LinkedList[T]) IsEmpty() bool {
+	return l.size == 0
+}
+
+// Size returns the number of items in this LinkedList.
+func (l *LinkedList[T]) Size() int {
+	return l.size
+}
+
+// Front returns the first Element of this LinkedList or nil if the list is
+// empty.
+func (l *LinkedList[T]) Front() *Element[T] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last Element of this LinkedList or nil if the list is
+// empty.
+func (l *LinkedList[T]) Back() *Element[T] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront inserts the specified value at the front of this LinkedList and
+// returns its Element.
+func (l *LinkedList[T]) PushFront(value T) *Element[T] {
+	l.lazyInit()
+	return l.insertAfter(&Element[T]{Value: value}, &l.root)
+}
+
+// PushBack inserts the specified value at the back of this LinkedList and
+// returns its Element.
+func (l *LinkedList[T]) PushBack(value T) *Element[T] {
+	l.lazyInit()
+	return l.insertAfter(&Element[T]{Value: value}, l.root.prev)
+}
+
+// InsertBefore inserts the specified value immediately before the mark
+// Element and returns the new Element. If mark does not belong to this
+// LinkedList, the list is not modified and nil is returned.
+func (l *LinkedList[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insertAfter(&Element[T]{Value: value}, mark.prev)
+}
+
+// InsertAfter inserts the specified value immediately after the mark
+// Element and returns the new Element. If mark does not belong to this
+// LinkedList, the list is not modified and nil is returned.
+func (l *LinkedList[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insertAfter(&Element[T]{Value: value}, mark)
+}
+
+// Remove removes the specified Element from this LinkedList. This method
+// returns true if the Element was part of this LinkedList and false
+// otherwise.
+func (l *LinkedList[T]) Remove(element *Element[T]) bool {
+	if element.list != l {
+		return false
+	}
+	l.unlink(element)
+	element.next = nil
+	element.prev = nil
+	element.list = nil
+	return true
+}
+
+// MoveToFront moves the specified Element to the front of this LinkedList.
+// If the Element does not belong to this LinkedList, the list is not
+// modified.
+func (l *LinkedList[T]) MoveToFront(element *Element[T]) {
+	if element.list != l || l.root.next == element {
+		return
+	}
+	l.unlink(element)
+	l.insertAfter(element, &l.root)
+}
+
+// MoveToBack moves the specified Element to the back of this LinkedList.
+// If the Element does not belong to this LinkedList, the list is not
+// modified.
+func (l *LinkedList[T]) MoveToBack(element *Element[T]) {
+	if element.list != l || l.root.prev == element {
+		return
+	}
+	l.unlink(element)
+	l.insertAfter(element, l.root.prev)
+}
+
+// Splice moves all Elements of the other LinkedList to the back of this
+// LinkedList, leaving the other list empty. Existing Element handles remain
+// valid and from then on belong to this LinkedList.
+//
+// This operation takes time proportional to the size of the other list.
+func (l *LinkedList[T]) Splice(other *LinkedList[T]) {
+	if other == l || other.size == 0 {
+		return
+	}
+	l.lazyInit()
+	for element := other.root.next; element != &other.root; element = element.next {
+		element.list = l
+	}
+	first := other.root.next
+	last := other.root.prev
+	first.prev = l.root.prev
+	last.next = &l.root
+	l.root.prev.next = first
+	l.root.prev = last
+	l.size += other.size
+
+	other.root.next = &other.root
+	other.root.prev = &other.root
+	other.size = 0
+}
+
+// All returns a sequence over the items of this LinkedList from front to
+// back.
+//
+// The LinkedList should not be modified during iteration.
+func (l *LinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if l.size == 0 {
+			return
+		}
+		for element := l.root.next; element != &l.root; element = element.next {
+			if !yield(element.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns a sequence over the items of this LinkedList from back
+// to front.
+//
+// The LinkedList should not be modified during iteration.
+func (l *LinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if l.size == 0 {
+			return
+		}
+		for element := l.root.prev; element != &l.root; element = element.prev {
+			if !yield(element.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Items returns a slice containing all of the items of this LinkedList from
+// front to back.
+func (l *LinkedList[T]) Items() []T {
+	result := make([]T, 0, l.size)
+	if l.size == 0 {
+		return result
+	}
+	for element := l.root.next; element != &l.root; element = element.next {
+		result = append(result, element.Value)
+	}
+	return result
+}
+
+// Clear removes all items from this LinkedList. Existing Element handles
+// no longer belong to the list afterwards.
+func (l *LinkedList[T]) Clear() {
+	l.lazyInit()
+	for element := l.root.next; element != &l.root; {
+		next := element.next
+		element.next = nil
+		element.prev = nil
+		element.list = nil
+		element = next
+	}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.size = 0
+}
+
+// MarshalJSON encodes this LinkedList as a JSON array, from front to back.
+func (l *LinkedList[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(l.Items())
+}
+
+// UnmarshalJSON decodes a JSON array into this LinkedList, replacing any
+// existing items. Previously issued Element handles no longer belong to the
+// list afterwards.
+func (l *LinkedList[T]) UnmarshalJSON(data []byte) error {
+	items, err := unmarshalItemsJSON[T](data)
+	if err != nil {
+		return err
+	}
+	l.replace(items)
+	return nil
+}
+
+// MarshalBinary encodes this LinkedList using encoding/gob.
+func (l *LinkedList[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(l.Items())
+}
+
+// UnmarshalBinary decodes a LinkedList that was encoded with MarshalBinary,
+// replacing any existing items. Previously issued Element handles no longer
+// belong to the list afterwards.
+func (l *LinkedList[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalItemsBinary[T](data)
+	if err != nil {
+		return err
+	}
+	l.replace(items)
+	return nil
+}
+
+func (l *LinkedList[T]) replace(items []T) {
+	l.Clear()
+	for _, item := range items {
+		l.PushBack(item)
+	}
+}
+
+func (l *LinkedList[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+func (l *LinkedList[T]) insertAfter(element, mark *Element[T]) *Element[T] {
+	element.prev = mark
+	element.next = mark.next
+	mark.next.prev = element
+	mark.next = element
+	element.list = l
+	l.size++
+	return element
+}
+
+func (l *LinkedList[T]) unlink(element *Element[T]) {
+	element.prev.next = element.next
+	element.next.prev = element.prev
+	l.size--
+}
+
+// Element is a handle to an item stored in a LinkedList.
+type Element[T any] struct {
+	next *Element[T]
+	prev *Element[T]
+	list *LinkedList[T]
+
+	// Value holds the item that is stored in this Element.
+	Value T
+}
+
+// Next returns the next Element in the LinkedList or nil if this is the
+// last one or the Element has been removed.
+func (e *Element[T]) Next() *Element[T] {
+	if e.list == nil || e.next == &e.list.root {
+		return nil
+	}
+	return e.next
+}
+
+// Prev returns the previous Element in the LinkedList or nil if this is the
+// first one or the Element has been removed.
+func (e *Element[T]) Prev() *Element[T] {
+	if e.list == nil || e.prev == &e.list.root {
+		return nil
+	}
+	return e.prev
+}
@@ -0,0 +1,176 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("LinkedList", func() {
+	var list *ds.LinkedList[string]
+
+	BeforeEach(func() {
+		list = ds.NewLinkedList[string]()
+	})
+
+	It("is empty by default", func() {
+		Expect(list.IsEmpty()).To(BeTrue())
+		Expect(list.Size()).To(BeZero())
+		Expect(list.Front()).To(BeNil())
+		Expect(list.Back()).To(BeNil())
+		Expect(list.Items()).To(BeEmpty())
+	})
+
+	When("items are pushed", func() {
+		var first, second, third *ds.Element[string]
+
+		BeforeEach(func() {
+			second = list.PushBack("second")
+			third = list.PushBack("third")
+			first = list.PushFront("first")
+		})
+
+		It("has the correct size", func() {
+			Expect(list.IsEmpty()).To(BeFalse())
+			Expect(list.Size()).To(Equal(3))
+		})
+
+		It("has the correct front and back", func() {
+			Expect(list.Front()).To(BeIdenticalTo(first))
+			Expect(list.Back()).To(BeIdenticalTo(third))
+		})
+
+		It("links the elements", func() {
+			Expect(first.Prev()).To(BeNil())
+			Expect(first.Next()).To(BeIdenticalTo(second))
+			Expect(second.Next()).To(BeIdenticalTo(third))
+			Expect(third.Next()).To(BeNil())
+			Expect(third.Prev()).To(BeIdenticalTo(second))
+		})
+
+		It("iterates forward", func() {
+			Expect(slices.Collect(list.All())).To(Equal([]string{"first", "second", "third"}))
+		})
+
+		It("iterates backward", func() {
+			Expect(slices.Collect(list.Backward())).To(Equal([]string{"third", "second", "first"}))
+		})
+
+		It("supports early termination", func() {
+			var items []string
+			for item := range list.All() {
+				items = append(items, item)
+				break
+			}
+			Expect(items).To(Equal([]string{"first"}))
+		})
+
+		It("is possible to insert before an element", func() {
+			element := list.InsertBefore("middle", second)
+			Expect(element.Value).To(Equal("middle"))
+			Expect(list.Items()).To(Equal([]string{"first", "middle", "second", "third"}))
+		})
+
+		It("is possible to insert after an element", func() {
+			list.InsertAfter("last", third)
+			Expect(list.Items()).To(Equal([]string{"first", "second", "third", "last"}))
+			Expect(list.Back().Value).To(Equal("last"))
+		})
+
+		It("is possible to remove an element", func() {
+			Expect(list.Remove(second)).To(BeTrue())
+			Expect(list.Remove(second)).To(BeFalse())
+			Expect(list.Size()).To(Equal(2))
+			Expect(list.Items()).To(Equal([]string{"first", "third"}))
+			Expect(second.Next()).To(BeNil())
+		})
+
+		It("is possible to move an element to the front", func() {
+			list.MoveToFront(third)
+			Expect(list.Items()).To(Equal([]string{"third", "first", "second"}))
+			list.MoveToFront(third)
+			Expect(list.Items()).To(Equal([]string{"third", "first", "second"}))
+		})
+
+		It("is possible to move an element to the back", func() {
+			list.MoveToBack(first)
+			Expect(list.Items()).To(Equal([]string{"second", "third", "first"}))
+		})
+
+		It("is possible to modify values through elements", func() {
+			second.Value = "changed"
+			Expect(list.Items()).To(Equal([]string{"first", "changed", "third"}))
+		})
+
+		It("ignores elements of other lists", func() {
+			other := ds.NewLinkedList[string]()
+			foreign := other.PushBack("foreign")
+			Expect(list.InsertBefore("x", foreign)).To(BeNil())
+			Expect(list.InsertAfter("x", foreign)).To(BeNil())
+			Expect(list.Remove(foreign)).To(BeFalse())
+			list.MoveToFront(foreign)
+			list.MoveToBack(foreign)
+			Expect(list.Size()).To(Equal(3))
+			Expect(other.Items()).To(Equal([]string{"foreign"}))
+		})
+
+		It("is possible to splice another list", func() {
+			other := ds.LinkedListFromSlice([]string{"fourth", "fifth"})
+			fourth := other.Front()
+			list.Splice(other)
+			Expect(list.Size()).To(Equal(5))
+			Expect(list.Items()).To(Equal([]string{"first", "second", "third", "fourth", "fifth"}))
+			Expect(slices.Collect(list.Backward())).To(Equal([]string{"fifth", "fourth", "third", "second", "first"}))
+			Expect(other.IsEmpty()).To(BeTrue())
+			Expect(other.Items()).To(BeEmpty())
+			Expect(list.Remove(fourth)).To(BeTrue())
+			Expect(list.Items()).To(Equal([]string{"first", "second", "third", "fifth"}))
+		})
+
+		It("is possible to splice an empty list", func() {
+			list.Splice(ds.NewLinkedList[string]())
+			Expect(list.Size()).To(Equal(3))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				list.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(list.IsEmpty()).To(BeTrue())
+				Expect(list.Front()).To(BeNil())
+				Expect(list.Items()).To(BeEmpty())
+			})
+
+			It("detaches the elements", func() {
+				Expect(list.Remove(first)).To(BeFalse())
+				Expect(first.Next()).To(BeNil())
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON", func() {
+			list := ds.LinkedListFromSlice([]string{"a", "b", "c"})
+			data, err := json.Marshal(list)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`["a","b","c"]`))
+			var restored ds.LinkedList[string]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Items()).To(Equal([]string{"a", "b", "c"}))
+			restored.PushFront("z")
+			Expect(restored.Front().Value).To(Equal("z"))
+		})
+
+		It("round-trips through gob", func() {
+			var restored ds.LinkedList[string]
+			gobRoundTrip(ds.LinkedListFromSlice([]string{"a", "b"}), &restored)
+			Expect(restored.Items()).To(Equal([]string{"a", "b"}))
+		})
+	})
+})
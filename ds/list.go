@@ -1,12 +1,19 @@
 package ds
 
-import "slices"
+import (
+	"iter"
+	"slices"
+)
 
 // NewList creates a new List with the given capacity. The capacity can be
 // used as a form of optimization. Regardless of the value, the initial size
 // of the list is zero and the list can grow past the specified capacity.
 func NewList[T comparable](initialCapacity int) *List[T] {
-	return &List[T]{}
+	return &List[T]{
+		list: AnyList[T]{
+			items: make([]T, 0, initialCapacity),
+		},
+	}
 }
 
 // ListFromSlice constructs a new List that is based on the items from the
@@ -16,31 +23,57 @@ func NewList[T comparable](initialCapacity int) *List[T] {
 // internal copy.
 func ListFromSlice[T comparable](items []T) *List[T] {
 	return &List[T]{
-		items: slices.Clone(items),
+		list: AnyList[T]{
+			items: slices.Clone(items),
+		},
+	}
+}
+
+// ListFromSeq constructs a new List that contains the items of the specified
+// sequence.
+func ListFromSeq[T comparable](src iter.Seq[T]) *List[T] {
+	return &List[T]{
+		list: AnyList[T]{
+			items: slices.Collect(src),
+		},
 	}
 }
 
 // List represents a sequence of items.
 //
-// Currently a List can only store comparable items. This restriction
-// allows for Remove, IndexOf and Contains operations.
+// A List can only store comparable items. This restriction allows for
+// Remove, IndexOf and Contains operations. Use AnyList for items that
+// are not comparable.
 type List[T comparable] struct {
-	items []T
+	list AnyList[T]
 }
 
 // Size returns the number of items contained in this List.
 func (l *List[T]) Size() int {
-	return len(l.items)
+	return l.list.Size()
 }
 
 // IsEmpty returns whether this list has no elements.
 func (l *List[T]) IsEmpty() bool {
-	return len(l.items) == 0
+	return l.list.IsEmpty()
 }
 
 // Add appends the specified item to the List.
 func (l *List[T]) Add(item T) {
-	l.items = append(l.items, item)
+	l.list.Add(item)
+}
+
+// AddAll appends the specified items to the List.
+func (l *List[T]) AddAll(items ...T) {
+	l.list.AddAll(items...)
+}
+
+// Insert inserts the specified item at the specified index, shifting the
+// item at that index and all following items to the right.
+//
+// This method will panic if the index is outside the range [0, Size()].
+func (l *List[T]) Insert(index int, item T) {
+	l.list.Insert(index, item)
 }
 
 // Remove removes the specified item from this List and returns true. If the
@@ -50,23 +83,64 @@ func (l *List[T]) Remove(item T) bool {
 	if index < 0 {
 		return false
 	}
-	l.items = slices.Delete(l.items, index, index+1)
+	l.list.RemoveAt(index)
 	return true
 }
 
+// RemoveAt removes the item at the specified index and returns it, shifting
+// all following items to the left.
+//
+// This method will panic if the index is outside the list bounds.
+func (l *List[T]) RemoveAt(index int) T {
+	return l.list.RemoveAt(index)
+}
+
+// RemoveFunc removes the first item for which the specified function returns
+// true. This method returns true if such an item was found and false
+// otherwise.
+func (l *List[T]) RemoveFunc(fn func(item T) bool) bool {
+	return l.list.RemoveFunc(fn)
+}
+
 // Get returns the item in this list that is located at the specified index
 // (starting from zero).
 //
 // This method will panic if the index is outside the list bounds.
 func (l *List[T]) Get(index int) T {
-	return l.items[index]
+	return l.list.Get(index)
 }
 
 // Set modifies the item at the specified index.
 //
 // This method will panic if the index is outside the list bounds.
 func (l *List[T]) Set(index int, value T) {
-	l.items[index] = value
+	l.list.Set(index, value)
+}
+
+// Swap exchanges the items at the two specified indices.
+//
+// This method will panic if either index is outside the list bounds.
+func (l *List[T]) Swap(i, j int) {
+	l.list.Swap(i, j)
+}
+
+// Reverse reverses the order of the items in this List.
+func (l *List[T]) Reverse() {
+	l.list.Reverse()
+}
+
+// SortFunc sorts the items in this List in ascending order as determined by
+// the specified compare function. The sort is stable.
+func (l *List[T]) SortFunc(compare func(a, b T) int) {
+	l.list.SortFunc(compare)
+}
+
+// SubList returns a new List that contains a copy of the items in the range
+// [from, to).
+//
+// This method will panic if the range is outside the list bounds.
+func (l *List[T]) SubList(from, to int) *List[T] {
+	return ListFromSlice(l.list.items[from:to])
 }
 
 // Unbox provides direct access to the inner representation of the list.
@@ -77,7 +151,7 @@ func (l *List[T]) Set(index int, value T) {
 // This method should only be used when performance is critical and memory
 // allocation is not desired.
 func (l *List[T]) Unbox() []T {
-	return l.items
+	return l.list.Unbox()
 }
 
 // Items returns all items stored in this List as a slice. It is safe to mutate
@@ -85,7 +159,7 @@ func (l *List[T]) Unbox() []T {
 //
 // If performance is needed, consider using Unbox method instead.
 func (l *List[T]) Items() []T {
-	return slices.Clone(l.items)
+	return l.list.Items()
 }
 
 // Contains checks whether this List has the specified item and returns true
@@ -94,31 +168,80 @@ func (l *List[T]) Contains(item T) bool {
 	return l.IndexOf(item) >= 0
 }
 
+// ContainsFunc returns whether this List has at least one item for which
+// the specified function returns true.
+func (l *List[T]) ContainsFunc(fn func(item T) bool) bool {
+	return l.list.ContainsFunc(fn)
+}
+
 // IndexOf returns the index where the specified item is located in this List.
 // If the item is not part of this list, this method returns -1.
 func (l *List[T]) IndexOf(item T) int {
-	return slices.Index(l.items, item)
+	return slices.Index(l.list.items, item)
+}
+
+// IndexFunc returns the index of the first item for which the specified
+// function returns true. If there is no such item, this method returns -1.
+func (l *List[T]) IndexFunc(fn func(item T) bool) int {
+	return l.list.IndexFunc(fn)
+}
+
+// All returns a sequence over all items in this List, in order.
+func (l *List[T]) All() iter.Seq[T] {
+	return l.list.All()
+}
+
+// Indexed returns a sequence over all items in this List, in order, paired
+// with their indices.
+func (l *List[T]) Indexed() iter.Seq2[int, T] {
+	return l.list.Indexed()
 }
 
 // Each is a helper method allows one to iterate over all items in this List
 // through a closure function.
 func (l *List[T]) Each(iterator func(item T)) {
-	for _, item := range l.items {
-		iterator(item)
-	}
+	l.list.Each(iterator)
 }
 
 // Equals returns whether this list matches exactly the provided list.
 func (l *List[T]) Equals(other *List[T]) bool {
-	return slices.Equal(l.items, other.items)
+	return slices.Equal(l.list.items, other.list.items)
+}
+
+// EqualsFunc returns whether this List matches the provided List, using the
+// specified function to compare items.
+func (l *List[T]) EqualsFunc(other *List[T], eq func(a, b T) bool) bool {
+	return l.list.EqualsFunc(&other.list, eq)
 }
 
 // Clear removes all items from this List.
 func (l *List[T]) Clear() {
-	l.items = l.items[:0]
+	l.list.Clear()
 }
 
 // Clip removes unused capacity from the List.
 func (l *List[T]) Clip() {
-	l.items = slices.Clip(l.items)
+	l.list.Clip()
+}
+
+// MarshalJSON encodes this List as a JSON array.
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	return l.list.MarshalJSON()
+}
+
+// UnmarshalJSON decodes a JSON array into this List, replacing any existing
+// items.
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	return l.list.UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes this List using encoding/gob.
+func (l *List[T]) MarshalBinary() ([]byte, error) {
+	return l.list.MarshalBinary()
+}
+
+// UnmarshalBinary decodes a List that was encoded with MarshalBinary,
+// replacing any existing items.
+func (l *List[T]) UnmarshalBinary(data []byte) error {
+	return l.list.UnmarshalBinary(data)
 }
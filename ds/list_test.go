@@ -1,6 +1,10 @@
 package ds_test
 
 import (
+	"encoding/json"
+	"slices"
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -28,6 +32,11 @@ var _ = Describe("List", func() {
 		Expect(list.Items()).To(BeEmpty())
 	})
 
+	It("preallocates the initial capacity", func() {
+		list = ds.NewList[string](16)
+		Expect(cap(list.Unbox())).To(Equal(16))
+	})
+
 	It("equals an empty list", func() {
 		other := ds.NewList[string](0)
 		Expect(list.Equals(other)).To(BeTrue())
@@ -88,10 +97,47 @@ var _ = Describe("List", func() {
 			Expect(list.IndexOf("missing")).To(Equal(-1))
 		})
 
+		It("supports index and bulk operations", func() {
+			list.Insert(1, "inserted")
+			list.AddAll("fourth", "fifth")
+			Expect(list.RemoveAt(0)).To(Equal("first"))
+			list.Swap(0, 1)
+			Expect(list.Items()).To(Equal([]string{
+				"second", "inserted", "third", "fourth", "fifth",
+			}))
+			list.Reverse()
+			Expect(list.IndexFunc(func(item string) bool {
+				return item == "third"
+			})).To(Equal(2))
+		})
+
+		It("is possible to compare with a function", func() {
+			other := ds.ListFromSlice([]string{"FIRST", "SECOND", "THIRD"})
+			Expect(list.EqualsFunc(other, strings.EqualFold)).To(BeTrue())
+		})
+
+		It("is possible to get a sub list", func() {
+			subList := list.SubList(1, 3)
+			Expect(subList.Items()).To(Equal([]string{"second", "third"}))
+			Expect(subList.Contains("second")).To(BeTrue())
+		})
+
 		It("ignores remove operations on missing items", func() {
 			Expect(list.Remove("missing")).To(BeFalse())
 		})
 
+		It("is possible to iterate with a sequence", func() {
+			Expect(slices.Collect(list.All())).To(Equal([]string{
+				"first", "second", "third",
+			}))
+			var indices []int
+			for index, item := range list.Indexed() {
+				Expect(item).To(Equal(list.Get(index)))
+				indices = append(indices, index)
+			}
+			Expect(indices).To(Equal([]int{0, 1, 2}))
+		})
+
 		It("is possible to iterate over the list", func() {
 			seen := make([]string, 0)
 			list.Each(func(item string) {
@@ -179,6 +225,16 @@ var _ = Describe("List", func() {
 		})
 	})
 
+	When("constructed from a sequence", func() {
+		BeforeEach(func() {
+			list = ds.ListFromSeq(slices.Values([]string{"a", "b", "c"}))
+		})
+
+		It("contains the items of the sequence", func() {
+			Expect(list.Items()).To(Equal([]string{"a", "b", "c"}))
+		})
+	})
+
 	When("constructed from a slice", func() {
 		BeforeEach(func() {
 			list = ds.ListFromSlice([]string{"a", "b", "c"})
@@ -205,4 +261,34 @@ var _ = Describe("List", func() {
 			})
 		})
 	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON", func() {
+			list := ds.ListFromSlice([]string{"c", "a", "b"})
+			data, err := json.Marshal(list)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`["c","a","b"]`))
+			var restored ds.List[string]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Equals(list)).To(BeTrue())
+		})
+
+		It("marshals an empty list to an empty array", func() {
+			data, err := json.Marshal(ds.NewList[string](0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[]`))
+		})
+
+		It("unmarshals null into an empty list", func() {
+			list := ds.ListFromSlice([]string{"a"})
+			Expect(list.UnmarshalJSON([]byte(`null`))).To(Succeed())
+			Expect(list.IsEmpty()).To(BeTrue())
+		})
+
+		It("round-trips through gob", func() {
+			var restored ds.List[string]
+			gobRoundTrip(ds.ListFromSlice([]string{"a", "b"}), &restored)
+			Expect(restored.Items()).To(Equal([]string{"a", "b"}))
+		})
+	})
 })
@@ -0,0 +1,288 @@
+package ds
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+)
+
+// NewLRU creates a new LRU cache instance that can hold up to the specified
+// capacity of entries. Once the capacity is reached, adding a new entry
+// evicts the least recently used one.
+//
+// This function panics if the capacity is not positive.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity <= 0 {
+		panic("ds: lru capacity must be positive")
+	}
+	result := &LRU[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*lruEntry[K, V], capacity),
+	}
+	result.root.next = &result.root
+	result.root.prev = &result.root
+	return result
+}
+
+// LRU is a cache data structure that keeps up to a fixed number of entries
+// and evicts the least recently used one when full. All operations are
+// performed in O(1) time.
+type LRU[K comparable, V any] struct {
+	capacity int
+	entries  map[K]*lruEntry[K, V]
+	root     lruEntry[K, V]
+	onEvict  func(key K, value V)
+}
+
+// OnEvict registers a callback function that is called whenever an entry is
+// evicted due to the capacity being exceeded. Entries that are removed
+// explicitly through Remove or Clear do not trigger the callback.
+//
+// Passing nil removes the callback.
+func (c *LRU[K, V]) OnEvict(callback func(key K, value V)) {
+	c.onEvict = callback
+}
+
+// Capacity returns the maximum number of entries that this LRU can hold.
+func (c *LRU[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Size returns the number of entries stored in this LRU.
+func (c *LRU[K, V]) Size() int {
+	return len(c.entries)
+}
+
+// IsEmpty returns true if there are no entries in this LRU.
+func (c *LRU[K, V]) IsEmpty() bool {
+	return len(c.entries) == 0
+}
+
+// Get returns the value stored for the specified key and marks the entry as
+// the most recently used one. The second return value is false if there is
+// no such entry.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.moveToFront(entry)
+	return entry.value, true
+}
+
+// Peek returns the value stored for the specified key, without affecting
+// how recently the entry has been used. The second return value is false
+// if there is no such entry.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Contains returns whether there is an entry for the specified key, without
+// affecting how recently the entry has been used.
+func (c *LRU[K, V]) Contains(key K) bool {
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Put stores the specified value for the specified key and marks the entry
+// as the most recently used one. If the LRU is full, the least recently used
+// entry is evicted.
+func (c *LRU[K, V]) Put(key K, value V) {
+	if entry, ok := c.entries[key]; ok {
+		entry.value = value
+		c.moveToFront(entry)
+		return
+	}
+	var entry *lruEntry[K, V]
+	if len(c.entries) >= c.capacity {
+		entry = c.evictOldest()
+	} else {
+		entry = &lruEntry[K, V]{}
+	}
+	entry.key = key
+	entry.value = value
+	c.entries[key] = entry
+	c.insertFront(entry)
+}
+
+// Remove removes the entry for the specified key. This method returns true
+// if there was such an entry and false otherwise.
+func (c *LRU[K, V]) Remove(key K) bool {
+	entry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	c.unlink(entry)
+	delete(c.entries, key)
+	return true
+}
+
+// Resize changes the capacity of this LRU. If the new capacity is smaller
+// than the current size, the least recently used entries are evicted.
+//
+// This method panics if the capacity is not positive.
+func (c *LRU[K, V]) Resize(capacity int) {
+	if capacity <= 0 {
+		panic("ds: lru capacity must be positive")
+	}
+	c.capacity = capacity
+	for len(c.entries) > capacity {
+		c.evictOldest()
+	}
+}
+
+// All returns a sequence over all entries in this LRU, starting from the
+// most recently used one and ending with the least recently used one.
+//
+// Iterating does not affect how recently entries have been used. The LRU
+// should not be modified during iteration.
+func (c *LRU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for entry := c.root.next; entry != &c.root; entry = entry.next {
+			if !yield(entry.key, entry.value) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all entries from this LRU.
+func (c *LRU[K, V]) Clear() {
+	clear(c.entries)
+	c.root.next = &c.root
+	c.root.prev = &c.root
+}
+
+// MarshalJSON encodes this LRU as a JSON object that holds the capacity and
+// the entries from the most recently used to the least recently used one.
+func (c *LRU[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.encoded())
+}
+
+// UnmarshalJSON decodes a JSON object that was produced by MarshalJSON into
+// this LRU. Existing entries are removed and the capacity is replaced. The
+// eviction callback, if any, is kept and is not called.
+func (c *LRU[K, V]) UnmarshalJSON(data []byte) error {
+	var encoded lruEncoding[K, V]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return c.replace(encoded)
+}
+
+// MarshalBinary encodes this LRU using encoding/gob.
+func (c *LRU[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalGob(c.encoded())
+}
+
+// UnmarshalBinary decodes an LRU that was encoded with MarshalBinary.
+// Existing entries are removed and the capacity is replaced.
+func (c *LRU[K, V]) UnmarshalBinary(data []byte) error {
+	var encoded lruEncoding[K, V]
+	if err := unmarshalGob(data, &encoded); err != nil {
+		return err
+	}
+	return c.replace(encoded)
+}
+
+func (c *LRU[K, V]) encoded() lruEncoding[K, V] {
+	entries := make([]keyValue[K, V], 0, len(c.entries))
+	if len(c.entries) > 0 {
+		for key, value := range c.All() {
+			entries = append(entries, keyValue[K, V]{
+				Key:   key,
+				Value: value,
+			})
+		}
+	}
+	return lruEncoding[K, V]{
+		Capacity: c.capacity,
+		Entries:  entries,
+	}
+}
+
+// replace resets this LRU to the encoded state. As with NewLRU, the
+// capacity needs to be positive.
+func (c *LRU[K, V]) replace(encoded lruEncoding[K, V]) error {
+	if encoded.Capacity <= 0 {
+		return errors.New("lru capacity must be positive")
+	}
+	if len(encoded.Entries) > encoded.Capacity {
+		return errors.New("lru entries exceed capacity")
+	}
+	entries := make(map[K]*lruEntry[K, V], encoded.Capacity)
+	for _, entry := range encoded.Entries {
+		if _, ok := entries[entry.Key]; ok {
+			return errors.New("lru contains duplicate keys")
+		}
+		entries[entry.Key] = &lruEntry[K, V]{
+			key:   entry.Key,
+			value: entry.Value,
+		}
+	}
+	c.capacity = encoded.Capacity
+	c.entries = entries
+	c.root.next = &c.root
+	c.root.prev = &c.root
+	for _, entry := range encoded.Entries {
+		c.insertBack(entries[entry.Key])
+	}
+	return nil
+}
+
+type lruEncoding[K comparable, V any] struct {
+	Capacity int              `json:"capacity"`
+	Entries  []keyValue[K, V] `json:"entries"`
+}
+
+func (c *LRU[K, V]) evictOldest() *lruEntry[K, V] {
+	entry := c.root.prev
+	c.unlink(entry)
+	delete(c.entries, entry.key)
+	if c.onEvict != nil {
+		c.onEvict(entry.key, entry.value)
+	}
+	return entry
+}
+
+func (c *LRU[K, V]) moveToFront(entry *lruEntry[K, V]) {
+	if c.root.next == entry {
+		return
+	}
+	c.unlink(entry)
+	c.insertFront(entry)
+}
+
+func (c *LRU[K, V]) insertFront(entry *lruEntry[K, V]) {
+	entry.prev = &c.root
+	entry.next = c.root.next
+	entry.prev.next = entry
+	entry.next.prev = entry
+}
+
+func (c *LRU[K, V]) insertBack(entry *lruEntry[K, V]) {
+	entry.next = &c.root
+	entry.prev = c.root.prev
+	entry.prev.next = entry
+	entry.next.prev = entry
+}
+
+func (c *LRU[K, V]) unlink(entry *lruEntry[K, V]) {
+	entry.prev.next = entry.next
+	entry.next.prev = entry.prev
+	entry.prev = nil
+	entry.next = nil
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *lruEntry[K, V]
+	next  *lruEntry[K, V]
+}
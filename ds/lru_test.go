@@ -0,0 +1,214 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("LRU", func() {
+	var (
+		cache   *ds.LRU[string, int]
+		evicted []string
+	)
+
+	keys := func() []string {
+		return slices.Collect(func(yield func(string) bool) {
+			for key := range cache.All() {
+				if !yield(key) {
+					return
+				}
+			}
+		})
+	}
+
+	BeforeEach(func() {
+		evicted = nil
+		cache = ds.NewLRU[string, int](3)
+		cache.OnEvict(func(key string, value int) {
+			evicted = append(evicted, key)
+		})
+	})
+
+	It("panics when created with non-positive capacity", func() {
+		Expect(func() { ds.NewLRU[string, int](0) }).To(Panic())
+	})
+
+	It("is empty by default", func() {
+		Expect(cache.IsEmpty()).To(BeTrue())
+		Expect(cache.Size()).To(BeZero())
+	})
+
+	It("has the configured capacity", func() {
+		Expect(cache.Capacity()).To(Equal(3))
+	})
+
+	It("returns false for missing keys", func() {
+		_, ok := cache.Get("missing")
+		Expect(ok).To(BeFalse())
+		_, ok = cache.Peek("missing")
+		Expect(ok).To(BeFalse())
+		Expect(cache.Remove("missing")).To(BeFalse())
+	})
+
+	When("entries are added", func() {
+		BeforeEach(func() {
+			cache.Put("a", 1)
+			cache.Put("b", 2)
+			cache.Put("c", 3)
+		})
+
+		It("has the correct size", func() {
+			Expect(cache.Size()).To(Equal(3))
+			Expect(cache.IsEmpty()).To(BeFalse())
+		})
+
+		It("is possible to get the values", func() {
+			value, ok := cache.Get("b")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(2))
+		})
+
+		It("iterates from most to least recently used", func() {
+			Expect(keys()).To(Equal([]string{"c", "b", "a"}))
+			Expect(maps.Collect(cache.All())).To(Equal(map[string]int{
+				"a": 1, "b": 2, "c": 3,
+			}))
+		})
+
+		It("updates recency on Get", func() {
+			cache.Get("a")
+			Expect(keys()).To(Equal([]string{"a", "c", "b"}))
+		})
+
+		It("does not update recency on Peek", func() {
+			value, ok := cache.Peek("a")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(1))
+			Expect(keys()).To(Equal([]string{"c", "b", "a"}))
+		})
+
+		It("updates value and recency on Put of existing key", func() {
+			cache.Put("a", 10)
+			Expect(keys()).To(Equal([]string{"a", "c", "b"}))
+			value, _ := cache.Peek("a")
+			Expect(value).To(Equal(10))
+			Expect(evicted).To(BeEmpty())
+		})
+
+		It("evicts the least recently used entry when full", func() {
+			cache.Get("a")
+			cache.Put("d", 4)
+			Expect(cache.Size()).To(Equal(3))
+			Expect(cache.Contains("b")).To(BeFalse())
+			Expect(evicted).To(Equal([]string{"b"}))
+			Expect(keys()).To(Equal([]string{"d", "a", "c"}))
+		})
+
+		It("is possible to remove entries", func() {
+			Expect(cache.Remove("b")).To(BeTrue())
+			Expect(cache.Contains("b")).To(BeFalse())
+			Expect(cache.Size()).To(Equal(2))
+			Expect(keys()).To(Equal([]string{"c", "a"}))
+			Expect(evicted).To(BeEmpty())
+		})
+
+		It("evicts entries when shrunk", func() {
+			cache.Resize(1)
+			Expect(cache.Capacity()).To(Equal(1))
+			Expect(keys()).To(Equal([]string{"c"}))
+			Expect(evicted).To(Equal([]string{"a", "b"}))
+		})
+
+		It("keeps entries when grown", func() {
+			cache.Resize(5)
+			cache.Put("d", 4)
+			cache.Put("e", 5)
+			Expect(cache.Size()).To(Equal(5))
+			Expect(evicted).To(BeEmpty())
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				cache.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(cache.IsEmpty()).To(BeTrue())
+				Expect(keys()).To(BeEmpty())
+				Expect(evicted).To(BeEmpty())
+			})
+
+			It("can be reused", func() {
+				cache.Put("x", 1)
+				Expect(keys()).To(Equal([]string{"x"}))
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		BeforeEach(func() {
+			cache.Put("a", 1)
+			cache.Put("b", 2)
+			cache.Put("c", 3)
+			cache.Get("a")
+		})
+
+		It("round-trips through JSON from most to least recently used", func() {
+			data, err := json.Marshal(cache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"capacity":3,"entries":[` +
+				`{"key":"a","value":1},{"key":"c","value":3},{"key":"b","value":2}]}`))
+
+			var restored ds.LRU[string, int]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Capacity()).To(Equal(3))
+			var restoredKeys []string
+			for key := range restored.All() {
+				restoredKeys = append(restoredKeys, key)
+			}
+			Expect(restoredKeys).To(Equal([]string{"a", "c", "b"}))
+			restored.Put("d", 4)
+			Expect(restored.Contains("b")).To(BeFalse())
+			Expect(restored.Contains("a")).To(BeTrue())
+		})
+
+		It("keeps the eviction callback when unmarshaling", func() {
+			Expect(json.Unmarshal([]byte(`{"capacity":2,"entries":[{"key":"x","value":1},{"key":"y","value":2}]}`), cache)).To(Succeed())
+			Expect(keys()).To(Equal([]string{"x", "y"}))
+			Expect(evicted).To(BeEmpty())
+			cache.Put("z", 3)
+			Expect(evicted).To(Equal([]string{"y"}))
+		})
+
+		It("marshals a zero value with no entries", func() {
+			var zero ds.LRU[string, int]
+			data, err := json.Marshal(&zero)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"capacity":0,"entries":[]}`))
+		})
+
+		It("fails to unmarshal invalid state", func() {
+			Expect(json.Unmarshal([]byte(`{"capacity":0,"entries":[]}`), cache)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`{"capacity":1,"entries":[{"key":"x","value":1},{"key":"y","value":2}]}`), cache)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`{"capacity":2,"entries":[{"key":"x","value":1},{"key":"x","value":2}]}`), cache)).ToNot(Succeed())
+			Expect(keys()).To(Equal([]string{"a", "c", "b"}))
+		})
+
+		It("round-trips through gob", func() {
+			var restored ds.LRU[string, int]
+			gobRoundTrip(cache, &restored)
+			Expect(restored.Capacity()).To(Equal(3))
+			data, err := json.Marshal(&restored)
+			Expect(err).ToNot(HaveOccurred())
+			expected, err := json.Marshal(cache)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(Equal(expected))
+		})
+	})
+})
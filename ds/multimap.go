@@ -0,0 +1,241 @@
+package ds
+
+import (
+	"iter"
+	"slices"
+)
+
+// NewMultiMap creates a new MultiMap instance with the specified initial
+// capacity for keys, which is only used to preallocate memory and does not
+// act as an upper bound. The same value can be stored multiple times for a
+// given key.
+func NewMultiMap[K comparable, V comparable](initialCapacity int) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		buckets: make(map[K][]V, initialCapacity),
+	}
+}
+
+// NewSetMultiMap creates a new MultiMap instance with the specified initial
+// capacity for keys, where each value can be stored at most once for a
+// given key.
+func NewSetMultiMap[K comparable, V comparable](initialCapacity int) *MultiMap[K, V] {
+	result := NewMultiMap[K, V](initialCapacity)
+	result.unique = make(map[K]map[V]struct{}, initialCapacity)
+	return result
+}
+
+// MultiMap represents a map data structure where each key can be associated
+// with multiple values. It is a replacement of the map[K][]V pattern.
+//
+// The values for a given key are kept in the order in which they were added.
+// Values need to be comparable, which is what allows ContainsEntry, Remove
+// and the unique variant created through NewSetMultiMap to work.
+//
+// ContainsEntry and Remove scan the values of the key in O(n) time. The
+// unique variant additionally indexes the values of each key, which makes
+// Put and ContainsEntry O(1), though Remove remains O(n) in order to
+// preserve the order of the values.
+type MultiMap[K comparable, V comparable] struct {
+	buckets map[K][]V
+	unique  map[K]map[V]struct{}
+	size    int
+}
+
+// IsEmpty returns true if there are no entries in this MultiMap.
+func (m *MultiMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Size returns the total number of key-value entries in this MultiMap.
+func (m *MultiMap[K, V]) Size() int {
+	return m.size
+}
+
+// KeyCount returns the number of distinct keys in this MultiMap.
+func (m *MultiMap[K, V]) KeyCount() int {
+	return len(m.buckets)
+}
+
+// Put associates the specified value with the specified key. This method
+// returns true if the entry was added and false if the MultiMap was created
+// through NewSetMultiMap and the entry was already present.
+func (m *MultiMap[K, V]) Put(key K, value V) bool {
+	if m.unique != nil {
+		values, ok := m.unique[key]
+		if !ok {
+			values = make(map[V]struct{})
+			m.unique[key] = values
+		}
+		if _, ok := values[value]; ok {
+			return false
+		}
+		values[value] = struct{}{}
+	}
+	m.buckets[key] = append(m.buckets[key], value)
+	m.size++
+	return true
+}
+
+// Get returns the values that are associated with the specified key. It is
+// safe to modify the returned slice, as it is a copy.
+func (m *MultiMap[K, V]) Get(key K) []V {
+	return slices.Clone(m.buckets[key])
+}
+
+// ContainsKey returns whether there are any values associated with the
+// specified key.
+func (m *MultiMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.buckets[key]
+	return ok
+}
+
+// ContainsEntry returns whether the specified value is associated with the
+// specified key.
+func (m *MultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	if m.unique != nil {
+		_, ok := m.unique[key][value]
+		return ok
+	}
+	return slices.Contains(m.buckets[key], value)
+}
+
+// Remove removes one occurrence of the specified value from the values of
+// the specified key. This method returns true if there was such an entry
+// and false otherwise.
+func (m *MultiMap[K, V]) Remove(key K, value V) bool {
+	bucket := m.buckets[key]
+	index := slices.Index(bucket, value)
+	if index < 0 {
+		return false
+	}
+	if m.unique != nil {
+		delete(m.unique[key], value)
+	}
+	if len(bucket) == 1 {
+		delete(m.buckets, key)
+		delete(m.unique, key)
+	} else {
+		m.buckets[key] = slices.Delete(bucket, index, index+1)
+	}
+	m.size--
+	return true
+}
+
+// RemoveAll removes all values associated with the specified key and
+// returns them.
+func (m *MultiMap[K, V]) RemoveAll(key K) []V {
+	bucket, ok := m.buckets[key]
+	if !ok {
+		return nil
+	}
+	delete(m.buckets, key)
+	delete(m.unique, key)
+	m.size -= len(bucket)
+	return bucket
+}
+
+// Keys returns a sequence over the distinct keys of this MultiMap.
+//
+// Note: The keys are returned in a random order.
+func (m *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.buckets {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence over all key-value entries of this MultiMap. A key
+// is repeated for each of its values.
+//
+// Note: The keys are returned in a random order, though the values of a
+// given key follow the order in which they were added.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, bucket := range m.buckets {
+			for _, value := range bucket {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clear removes all entries from this MultiMap.
+func (m *MultiMap[K, V]) Clear() {
+	clear(m.buckets)
+	clear(m.unique)
+	m.size = 0
+}
+
+// MarshalJSON encodes this MultiMap as a JSON array of objects, each
+// holding a key and the values associated with it. If the keys are of an
+// integer, float or string type, the objects are sorted by key so that the
+// output is deterministic.
+func (m *MultiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(m.entries())
+}
+
+// UnmarshalJSON decodes a JSON array that was produced by MarshalJSON into
+// this MultiMap. Existing entries are removed beforehand. If this MultiMap
+// was created through NewSetMultiMap, repeated values are ignored.
+func (m *MultiMap[K, V]) UnmarshalJSON(data []byte) error {
+	entries, err := unmarshalItemsJSON[multiMapEntry[K, V]](data)
+	if err != nil {
+		return err
+	}
+	m.replace(entries)
+	return nil
+}
+
+// MarshalBinary encodes this MultiMap using encoding/gob. If the keys are
+// of an integer, float or string type, they are sorted in ascending order
+// so that the output is deterministic.
+func (m *MultiMap[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(m.entries())
+}
+
+// UnmarshalBinary decodes a MultiMap that was encoded with MarshalBinary.
+// Existing entries are removed beforehand.
+func (m *MultiMap[K, V]) UnmarshalBinary(data []byte) error {
+	entries, err := unmarshalItemsBinary[multiMapEntry[K, V]](data)
+	if err != nil {
+		return err
+	}
+	m.replace(entries)
+	return nil
+}
+
+func (m *MultiMap[K, V]) entries() []multiMapEntry[K, V] {
+	result := make([]multiMapEntry[K, V], 0, len(m.buckets))
+	for key, bucket := range m.buckets {
+		result = append(result, multiMapEntry[K, V]{
+			Key:    key,
+			Values: bucket,
+		})
+	}
+	sortByOrderedKey(result, func(entry multiMapEntry[K, V]) K {
+		return entry.Key
+	})
+	return result
+}
+
+func (m *MultiMap[K, V]) replace(entries []multiMapEntry[K, V]) {
+	if m.buckets == nil {
+		m.buckets = make(map[K][]V, len(entries))
+	}
+	m.Clear()
+	for _, entry := range entries {
+		for _, value := range entry.Values {
+			m.Put(entry.Key, value)
+		}
+	}
+}
+
+type multiMapEntry[K comparable, V comparable] struct {
+	Key    K   `json:"key"`
+	Values []V `json:"values"`
+}
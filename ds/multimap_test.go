@@ -0,0 +1,178 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("MultiMap", func() {
+	var multiMap *ds.MultiMap[string, int]
+
+	BeforeEach(func() {
+		multiMap = ds.NewMultiMap[string, int](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(multiMap.IsEmpty()).To(BeTrue())
+		Expect(multiMap.Size()).To(BeZero())
+		Expect(multiMap.KeyCount()).To(BeZero())
+		Expect(multiMap.Get("missing")).To(BeEmpty())
+	})
+
+	When("entries are added", func() {
+		BeforeEach(func() {
+			Expect(multiMap.Put("odd", 1)).To(BeTrue())
+			Expect(multiMap.Put("even", 2)).To(BeTrue())
+			Expect(multiMap.Put("odd", 3)).To(BeTrue())
+			Expect(multiMap.Put("odd", 1)).To(BeTrue())
+		})
+
+		It("has the correct size", func() {
+			Expect(multiMap.IsEmpty()).To(BeFalse())
+			Expect(multiMap.Size()).To(Equal(4))
+			Expect(multiMap.KeyCount()).To(Equal(2))
+		})
+
+		It("returns the values of a key in order", func() {
+			Expect(multiMap.Get("odd")).To(Equal([]int{1, 3, 1}))
+			Expect(multiMap.Get("even")).To(Equal([]int{2}))
+		})
+
+		It("returns a copy of the values", func() {
+			values := multiMap.Get("odd")
+			values[0] = 100
+			Expect(multiMap.Get("odd")).To(Equal([]int{1, 3, 1}))
+		})
+
+		It("reports contained keys and entries", func() {
+			Expect(multiMap.ContainsKey("odd")).To(BeTrue())
+			Expect(multiMap.ContainsKey("missing")).To(BeFalse())
+			Expect(multiMap.ContainsEntry("odd", 3)).To(BeTrue())
+			Expect(multiMap.ContainsEntry("odd", 2)).To(BeFalse())
+		})
+
+		It("is possible to remove a single entry", func() {
+			Expect(multiMap.Remove("odd", 1)).To(BeTrue())
+			Expect(multiMap.Get("odd")).To(Equal([]int{3, 1}))
+			Expect(multiMap.Size()).To(Equal(3))
+			Expect(multiMap.Remove("odd", 5)).To(BeFalse())
+		})
+
+		It("removes keys without values", func() {
+			Expect(multiMap.Remove("even", 2)).To(BeTrue())
+			Expect(multiMap.ContainsKey("even")).To(BeFalse())
+			Expect(multiMap.KeyCount()).To(Equal(1))
+		})
+
+		It("is possible to remove all values of a key", func() {
+			Expect(multiMap.RemoveAll("odd")).To(Equal([]int{1, 3, 1}))
+			Expect(multiMap.ContainsKey("odd")).To(BeFalse())
+			Expect(multiMap.Size()).To(Equal(1))
+			Expect(multiMap.RemoveAll("odd")).To(BeNil())
+		})
+
+		It("iterates keys and entries", func() {
+			Expect(slices.Collect(multiMap.Keys())).To(ConsistOf("odd", "even"))
+			var values []int
+			for key, value := range multiMap.All() {
+				if key == "odd" {
+					values = append(values, value)
+				}
+			}
+			Expect(values).To(Equal([]int{1, 3, 1}))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				multiMap.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(multiMap.IsEmpty()).To(BeTrue())
+				Expect(multiMap.KeyCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("set-valued buckets", func() {
+		BeforeEach(func() {
+			multiMap = ds.NewSetMultiMap[string, int](0)
+		})
+
+		It("does not store duplicate values for a key", func() {
+			Expect(multiMap.Put("a", 1)).To(BeTrue())
+			Expect(multiMap.Put("a", 1)).To(BeFalse())
+			Expect(multiMap.Put("b", 1)).To(BeTrue())
+			Expect(multiMap.Get("a")).To(Equal([]int{1}))
+			Expect(multiMap.Size()).To(Equal(2))
+		})
+
+		It("keeps the values of a key in insertion order", func() {
+			multiMap.Put("a", 3)
+			multiMap.Put("a", 1)
+			multiMap.Put("a", 3)
+			multiMap.Put("a", 2)
+			Expect(multiMap.Get("a")).To(Equal([]int{3, 1, 2}))
+			Expect(multiMap.ContainsEntry("a", 1)).To(BeTrue())
+			Expect(multiMap.ContainsEntry("a", 4)).To(BeFalse())
+			Expect(multiMap.ContainsEntry("b", 1)).To(BeFalse())
+		})
+
+		It("allows values to be added again after removal", func() {
+			multiMap.Put("a", 1)
+			multiMap.Put("a", 2)
+			Expect(multiMap.Remove("a", 1)).To(BeTrue())
+			Expect(multiMap.Remove("a", 1)).To(BeFalse())
+			Expect(multiMap.ContainsEntry("a", 1)).To(BeFalse())
+			Expect(multiMap.Put("a", 1)).To(BeTrue())
+			Expect(multiMap.Get("a")).To(Equal([]int{2, 1}))
+
+			Expect(multiMap.RemoveAll("a")).To(Equal([]int{2, 1}))
+			Expect(multiMap.Put("a", 2)).To(BeTrue())
+
+			multiMap.Clear()
+			Expect(multiMap.ContainsEntry("a", 2)).To(BeFalse())
+			Expect(multiMap.Put("a", 2)).To(BeTrue())
+			Expect(multiMap.Size()).To(Equal(1))
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("marshals to a sorted JSON array of keys and values", func() {
+			multiMap := ds.NewMultiMap[string, int](0)
+			multiMap.Put("b", 2)
+			multiMap.Put("a", 3)
+			multiMap.Put("a", 1)
+			data, err := json.Marshal(multiMap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[{"key":"a","values":[3,1]},{"key":"b","values":[2]}]`))
+		})
+
+		It("unmarshals into a zero value", func() {
+			var multiMap ds.MultiMap[string, int]
+			Expect(json.Unmarshal([]byte(`[{"key":"a","values":[1,2]}]`), &multiMap)).To(Succeed())
+			Expect(multiMap.Get("a")).To(Equal([]int{1, 2}))
+			Expect(multiMap.Size()).To(Equal(2))
+		})
+
+		It("ignores repeated values in a set multimap", func() {
+			multiMap := ds.NewSetMultiMap[string, int](0)
+			Expect(json.Unmarshal([]byte(`[{"key":"a","values":[1,1]}]`), multiMap)).To(Succeed())
+			Expect(multiMap.Get("a")).To(Equal([]int{1}))
+		})
+
+		It("round-trips through gob", func() {
+			source := ds.NewMultiMap[int, string](0)
+			source.Put(1, "x")
+			source.Put(1, "y")
+			var restored ds.MultiMap[int, string]
+			gobRoundTrip(source, &restored)
+			Expect(restored.Get(1)).To(Equal([]string{"x", "y"}))
+		})
+	})
+})
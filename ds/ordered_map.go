@@ -0,0 +1,347 @@
+package ds
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// NewOrderedMap creates a new OrderedMap instance with the specified initial
+// capacity, which is only used to preallocate memory and does not act as an
+// upper bound.
+func NewOrderedMap[K comparable, V any](initialCapacity int) *OrderedMap[K, V] {
+	result := &OrderedMap[K, V]{
+		entries: make(map[K]*orderedMapEntry[K, V], initialCapacity),
+	}
+	result.root.next = &result.root
+	result.root.prev = &result.root
+	return result
+}
+
+// OrderedMap is a map data structure that remembers the order in which keys
+// were inserted. Iteration and JSON marshaling follow that order, which makes
+// the output deterministic.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*orderedMapEntry[K, V]
+	root    orderedMapEntry[K, V]
+}
+
+// Size returns the number of entries stored in this OrderedMap.
+func (m *OrderedMap[K, V]) Size() int {
+	return len(m.entries)
+}
+
+// IsEmpty returns true if there are no entries in this OrderedMap.
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return len(m.entries) == 0
+}
+
+// Set stores the specified value for the specified key. If the key is new,
+// it is placed at the back of the order. Otherwise, the value is replaced
+// and the key keeps its position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if entry, ok := m.entries[key]; ok {
+		entry.value = value
+		return
+	}
+	entry := &orderedMapEntry[K, V]{
+		key:   key,
+		value: value,
+	}
+	m.entries[key] = entry
+	m.insertBefore(entry, &m.root)
+}
+
+// Get returns the value stored for the specified key. The second return
+// value is false if there is no such key.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	entry, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Has returns whether this OrderedMap contains the specified key.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Delete removes the specified key from this OrderedMap. This method returns
+// true if there was such a key and false otherwise.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.unlink(entry)
+	delete(m.entries, key)
+	return true
+}
+
+// MoveToFront moves the specified key to the front of the order. This method
+// returns false if there is no such key.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.unlink(entry)
+	m.insertBefore(entry, m.root.next)
+	return true
+}
+
+// MoveToBack moves the specified key to the back of the order. This method
+// returns false if there is no such key.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.unlink(entry)
+	m.insertBefore(entry, &m.root)
+	return true
+}
+
+// Keys returns a sequence over the keys of this OrderedMap in order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for entry := m.front(); entry != &m.root; entry = entry.next {
+			if !yield(entry.key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence over the values of this OrderedMap in the order
+// of their keys.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for entry := m.front(); entry != &m.root; entry = entry.next {
+			if !yield(entry.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence over the key-value pairs of this OrderedMap in
+// order.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for entry := m.front(); entry != &m.root; entry = entry.next {
+			if !yield(entry.key, entry.value) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all entries from this OrderedMap.
+func (m *OrderedMap[K, V]) Clear() {
+	clear(m.entries)
+	m.root.next = &m.root
+	m.root.prev = &m.root
+}
+
+// MarshalJSON encodes this OrderedMap as a JSON object, where the keys
+// appear in order. Keys are encoded the same way that encoding/json encodes
+// map keys, which means that their type needs to be of a string or integer
+// kind, or implement encoding.TextMarshaler.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for entry := m.front(); entry != &m.root; entry = entry.next {
+		if entry != m.root.next {
+			buffer.WriteByte(',')
+		}
+		keyData, err := marshalJSONKey(entry.key)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(keyData)
+		buffer.WriteByte(':')
+		valueData, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling value for key %v: %w", entry.key, err)
+		}
+		buffer.Write(valueData)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into this OrderedMap, preserving the
+// order of the keys as they appear in the data. Existing entries are
+// removed beforehand, which means that a JSON null results in an empty
+// OrderedMap.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); token != nil && (!ok || delim != '{') {
+		return fmt.Errorf("expected JSON object but got %v", token)
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*orderedMapEntry[K, V])
+	}
+	m.Clear()
+	if token == nil {
+		return nil
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, err := unmarshalJSONKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("error unmarshaling value for key %q: %w", token, err)
+		}
+		m.Set(key, value)
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// MarshalBinary encodes this OrderedMap using encoding/gob, with the keys
+// in order.
+func (m *OrderedMap[K, V]) MarshalBinary() ([]byte, error) {
+	entries := make([]keyValue[K, V], 0, len(m.entries))
+	for key, value := range m.All() {
+		entries = append(entries, keyValue[K, V]{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return marshalItemsBinary(entries)
+}
+
+// UnmarshalBinary decodes an OrderedMap that was encoded with MarshalBinary,
+// preserving the order of the keys. Existing entries are removed
+// beforehand.
+func (m *OrderedMap[K, V]) UnmarshalBinary(data []byte) error {
+	entries, err := unmarshalItemsBinary[keyValue[K, V]](data)
+	if err != nil {
+		return err
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*orderedMapEntry[K, V], len(entries))
+	}
+	m.Clear()
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// front returns the first entry in the order. It is safe to use on a zero
+// value OrderedMap, in which case the root sentinel is returned.
+func (m *OrderedMap[K, V]) front() *orderedMapEntry[K, V] {
+	if m.root.next == nil {
+		return &m.root
+	}
+	return m.root.next
+}
+
+func (m *OrderedMap[K, V]) insertBefore(entry, mark *orderedMapEntry[K, V]) {
+	entry.prev = mark.prev
+	entry.next = mark
+	entry.prev.next = entry
+	entry.next.prev = entry
+}
+
+func (m *OrderedMap[K, V]) unlink(entry *orderedMapEntry[K, V]) {
+	entry.prev.next = entry.next
+	entry.next.prev = entry.prev
+	entry.prev = nil
+	entry.next = nil
+}
+
+type orderedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *orderedMapEntry[K, V]
+	next  *orderedMapEntry[K, V]
+}
+
+// marshalJSONKey encodes a key as a JSON string, following the documented
+// rules of encoding/json for map keys. Keys of a string kind are used as is,
+// keys that implement encoding.TextMarshaler are marshaled as text and keys
+// of an integer kind are formatted in base ten. Other key types are
+// rejected.
+func marshalJSONKey[K comparable](key K) ([]byte, error) {
+	value := reflect.ValueOf(&key).Elem()
+	var text string
+	switch {
+	case value.Kind() == reflect.String:
+		text = value.String()
+	case value.Type().Implements(textMarshalerType):
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			break
+		}
+		data, err := any(key).(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling key %v: %w", key, err)
+		}
+		text = string(data)
+	default:
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			text = strconv.FormatInt(value.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			text = strconv.FormatUint(value.Uint(), 10)
+		default:
+			return nil, fmt.Errorf("unsupported key type %v", value.Type())
+		}
+	}
+	return json.Marshal(text)
+}
+
+// unmarshalJSONKey decodes a key that was encoded with marshalJSONKey,
+// following the rules that encoding/json uses for map keys. Keys that
+// implement encoding.TextUnmarshaler take precedence over string kinds.
+func unmarshalJSONKey[K comparable](text string) (K, error) {
+	var key K
+	value := reflect.ValueOf(&key).Elem()
+	if unmarshaler, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(text)); err != nil {
+			return key, fmt.Errorf("error unmarshaling key %q: %w", text, err)
+		}
+		return key, nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(text, 10, 64)
+		if err != nil || value.OverflowInt(number) {
+			return key, fmt.Errorf("invalid key %q for type %v", text, value.Type())
+		}
+		value.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number, err := strconv.ParseUint(text, 10, 64)
+		if err != nil || value.OverflowUint(number) {
+			return key, fmt.Errorf("invalid key %q for type %v", text, value.Type())
+		}
+		value.SetUint(number)
+	default:
+		return key, fmt.Errorf("unsupported key type %v", value.Type())
+	}
+	return key, nil
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
@@ -0,0 +1,240 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("OrderedMap", func() {
+	var orderedMap *ds.OrderedMap[string, int]
+
+	BeforeEach(func() {
+		orderedMap = ds.NewOrderedMap[string, int](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(orderedMap.IsEmpty()).To(BeTrue())
+		Expect(orderedMap.Size()).To(BeZero())
+		Expect(slices.Collect(orderedMap.Keys())).To(BeEmpty())
+	})
+
+	It("returns false for missing keys", func() {
+		_, ok := orderedMap.Get("missing")
+		Expect(ok).To(BeFalse())
+		Expect(orderedMap.Has("missing")).To(BeFalse())
+		Expect(orderedMap.Delete("missing")).To(BeFalse())
+		Expect(orderedMap.MoveToFront("missing")).To(BeFalse())
+		Expect(orderedMap.MoveToBack("missing")).To(BeFalse())
+	})
+
+	When("entries are added", func() {
+		BeforeEach(func() {
+			orderedMap.Set("zulu", 1)
+			orderedMap.Set("alpha", 2)
+			orderedMap.Set("mike", 3)
+		})
+
+		It("has the correct size", func() {
+			Expect(orderedMap.Size()).To(Equal(3))
+			Expect(orderedMap.IsEmpty()).To(BeFalse())
+		})
+
+		It("is possible to get the values", func() {
+			value, ok := orderedMap.Get("alpha")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(2))
+			Expect(orderedMap.Has("mike")).To(BeTrue())
+		})
+
+		It("iterates in insertion order", func() {
+			Expect(slices.Collect(orderedMap.Keys())).To(Equal([]string{"zulu", "alpha", "mike"}))
+			Expect(slices.Collect(orderedMap.Values())).To(Equal([]int{1, 2, 3}))
+			var (
+				keys   []string
+				values []int
+			)
+			for key, value := range orderedMap.All() {
+				keys = append(keys, key)
+				values = append(values, value)
+			}
+			Expect(keys).To(Equal([]string{"zulu", "alpha", "mike"}))
+			Expect(values).To(Equal([]int{1, 2, 3}))
+		})
+
+		It("keeps the position of updated keys", func() {
+			orderedMap.Set("zulu", 10)
+			Expect(slices.Collect(orderedMap.Keys())).To(Equal([]string{"zulu", "alpha", "mike"}))
+			Expect(slices.Collect(orderedMap.Values())).To(Equal([]int{10, 2, 3}))
+		})
+
+		It("is possible to delete keys", func() {
+			Expect(orderedMap.Delete("alpha")).To(BeTrue())
+			Expect(orderedMap.Has("alpha")).To(BeFalse())
+			Expect(slices.Collect(orderedMap.Keys())).To(Equal([]string{"zulu", "mike"}))
+		})
+
+		It("appends re-added keys at the back", func() {
+			orderedMap.Delete("zulu")
+			orderedMap.Set("zulu", 4)
+			Expect(slices.Collect(orderedMap.Keys())).To(Equal([]string{"alpha", "mike", "zulu"}))
+		})
+
+		It("is possible to move keys to the front", func() {
+			Expect(orderedMap.MoveToFront("mike")).To(BeTrue())
+			Expect(slices.Collect(orderedMap.Keys())).To(Equal([]string{"mike", "zulu", "alpha"}))
+		})
+
+		It("is possible to move keys to the back", func() {
+			Expect(orderedMap.MoveToBack("zulu")).To(BeTrue())
+			Expect(slices.Collect(orderedMap.Keys())).To(Equal([]string{"alpha", "mike", "zulu"}))
+		})
+
+		It("marshals to JSON in insertion order", func() {
+			data, err := json.Marshal(orderedMap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"zulu":1,"alpha":2,"mike":3}`))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				orderedMap.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(orderedMap.IsEmpty()).To(BeTrue())
+				Expect(slices.Collect(orderedMap.Keys())).To(BeEmpty())
+			})
+		})
+	})
+
+	It("unmarshals from JSON preserving key order", func() {
+		err := json.Unmarshal([]byte(`{"b": 2, "c": 3, "a": 1}`), orderedMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(slices.Collect(orderedMap.Keys())).To(Equal([]string{"b", "c", "a"}))
+		Expect(slices.Collect(orderedMap.Values())).To(Equal([]int{2, 3, 1}))
+	})
+
+	It("unmarshals into a zero value", func() {
+		var target struct {
+			Items *ds.OrderedMap[string, int] `json:"items"`
+		}
+		err := json.Unmarshal([]byte(`{"items": {"y": 1, "x": 2}}`), &target)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(slices.Collect(target.Items.Keys())).To(Equal([]string{"y", "x"}))
+		target.Items.Set("z", 3)
+		Expect(slices.Collect(target.Items.Keys())).To(Equal([]string{"y", "x", "z"}))
+	})
+
+	It("marshals a zero value to an empty object", func() {
+		var source struct {
+			Items ds.OrderedMap[string, int] `json:"items"`
+		}
+		data, err := json.Marshal(&source)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"items":{}}`))
+		Expect(slices.Collect(source.Items.Keys())).To(BeEmpty())
+	})
+
+	It("removes existing entries when unmarshaling null", func() {
+		orderedMap.Set("a", 1)
+		Expect(orderedMap.UnmarshalJSON([]byte(`null`))).To(Succeed())
+		Expect(orderedMap.IsEmpty()).To(BeTrue())
+		Expect(slices.Collect(orderedMap.Keys())).To(BeEmpty())
+	})
+
+	It("unmarshals null into a zero value", func() {
+		var target ds.OrderedMap[string, int]
+		Expect(target.UnmarshalJSON([]byte(`null`))).To(Succeed())
+		Expect(target.IsEmpty()).To(BeTrue())
+		target.Set("a", 1)
+		Expect(target.Size()).To(Equal(1))
+	})
+
+	It("fails to unmarshal non-object JSON", func() {
+		err := json.Unmarshal([]byte(`[1, 2]`), orderedMap)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("supports integer keys in JSON", func() {
+		intMap := ds.NewOrderedMap[int, string](0)
+		intMap.Set(3, "three")
+		intMap.Set(1, "one")
+		data, err := json.Marshal(intMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"3":"three","1":"one"}`))
+
+		restored := ds.NewOrderedMap[int, string](0)
+		Expect(json.Unmarshal(data, restored)).To(Succeed())
+		Expect(slices.Collect(restored.Keys())).To(Equal([]int{3, 1}))
+	})
+
+	It("supports text marshaler keys in JSON", func() {
+		slotMap := ds.NewSlotMap[string](0)
+		first := slotMap.Insert("first")
+		second := slotMap.Insert("second")
+
+		handleMap := ds.NewOrderedMap[ds.SlotHandle, int](0)
+		handleMap.Set(second, 2)
+		handleMap.Set(first, 1)
+		data, err := json.Marshal(handleMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"1:1":2,"0:1":1}`))
+
+		expected, err := json.Marshal(map[ds.SlotHandle]int{first: 1})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(expected)).To(Equal(`{"0:1":1}`))
+
+		restored := ds.NewOrderedMap[ds.SlotHandle, int](0)
+		Expect(json.Unmarshal(data, restored)).To(Succeed())
+		Expect(slices.Collect(restored.Keys())).To(Equal([]ds.SlotHandle{second, first}))
+	})
+
+	It("rejects key types other than strings, integers and text marshalers", func() {
+		floatMap := ds.NewOrderedMap[float64, string](0)
+		floatMap.Set(1.5, "one and a half")
+		_, err := json.Marshal(floatMap)
+		Expect(err).To(HaveOccurred())
+
+		boolMap := ds.NewOrderedMap[bool, string](0)
+		boolMap.Set(true, "yes")
+		_, err = json.Marshal(boolMap)
+		Expect(err).To(HaveOccurred())
+
+		type point struct {
+			X, Y int
+		}
+		structMap := ds.NewOrderedMap[point, string](0)
+		structMap.Set(point{1, 2}, "a")
+		_, err = json.Marshal(structMap)
+		Expect(err).To(HaveOccurred())
+
+		Expect(json.Unmarshal([]byte(`{"1.5":"x"}`), floatMap)).ToNot(Succeed())
+		Expect(json.Unmarshal([]byte(`{"true":"x"}`), boolMap)).ToNot(Succeed())
+	})
+
+	It("rejects invalid integer keys in JSON", func() {
+		intMap := ds.NewOrderedMap[int8, string](0)
+		Expect(json.Unmarshal([]byte(`{"x":"a"}`), intMap)).ToNot(Succeed())
+		Expect(json.Unmarshal([]byte(`{"1.5":"a"}`), intMap)).ToNot(Succeed())
+		Expect(json.Unmarshal([]byte(`{"300":"a"}`), intMap)).ToNot(Succeed())
+
+		uintMap := ds.NewOrderedMap[uint, string](0)
+		Expect(json.Unmarshal([]byte(`{"-1":"a"}`), uintMap)).ToNot(Succeed())
+	})
+
+	It("round-trips through gob preserving order", func() {
+		source := ds.NewOrderedMap[string, int](0)
+		source.Set("c", 1)
+		source.Set("a", 2)
+		source.Set("b", 3)
+		var restored ds.OrderedMap[string, int]
+		gobRoundTrip(source, &restored)
+		Expect(slices.Collect(restored.Keys())).To(Equal([]string{"c", "a", "b"}))
+		Expect(slices.Collect(restored.Values())).To(Equal([]int{1, 2, 3}))
+	})
+})
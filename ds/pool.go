@@ -1,16 +1,68 @@
 package ds
 
+// PoolOptions holds the configuration of a Pool.
+type PoolOptions[T any] struct {
+	// Factory is used to create new items when none are available for reuse.
+	// If nil, new(T) is used.
+	Factory func() *T
+
+	// Reset, if specified, is called on every item that is restored to the
+	// pool, so that fetched items are always in a clean state.
+	Reset func(v *T)
+
+	// MaxSize limits the number of items that are retained for reuse. Items
+	// that are restored beyond this limit are dropped. A value of zero or
+	// less means that there is no limit.
+	MaxSize int
+}
+
+// PoolStats holds usage statistics of a Pool.
+type PoolStats struct {
+	// Hits is the number of fetches that were served by a reused item.
+	Hits int
+
+	// Misses is the number of fetches that required a new item to be
+	// created.
+	Misses int
+
+	// Drops is the number of restored items that were discarded because
+	// the pool was at its maximum size.
+	Drops int
+}
+
 // NewPool creates a new Pool instance.
 func NewPool[T any]() *Pool[T] {
+	return NewPoolWith(PoolOptions[T]{})
+}
+
+// NewPoolWith creates a new Pool instance that is configured according to
+// the specified options.
+func NewPoolWith[T any](opts PoolOptions[T]) *Pool[T] {
+	factory := opts.Factory
+	if factory == nil {
+		factory = func() *T {
+			return new(T)
+		}
+	}
 	return &Pool[T]{
-		items: NewStack[*T](0),
+		items:   NewStack[*T](0),
+		factory: factory,
+		reset:   opts.Reset,
+		maxSize: opts.MaxSize,
 	}
 }
 
 // Pool represents a storage structure that can preserve allocated objects
 // for faster reuse.
+//
+// Unlike sync.Pool, items are never evicted by the garbage collector, which
+// makes the behavior of the Pool deterministic.
 type Pool[T any] struct {
-	items *Stack[*T]
+	items   *Stack[*T]
+	factory func() *T
+	reset   func(v *T)
+	maxSize int
+	stats   PoolStats
 }
 
 // IsEmpty returns true if there is nothing stored for reuse in this pool.
@@ -18,6 +70,11 @@ func (p *Pool[T]) IsEmpty() bool {
 	return p.items.IsEmpty()
 }
 
+// Size returns the number of items that are stored for reuse in this pool.
+func (p *Pool[T]) Size() int {
+	return p.items.Size()
+}
+
 // Clear removes any items that were stored for reuse.
 func (p *Pool[T]) Clear() {
 	p.items.Clear()
@@ -27,12 +84,40 @@ func (p *Pool[T]) Clear() {
 // if one is not available.
 func (p *Pool[T]) Fetch() *T {
 	if p.items.IsEmpty() {
-		return new(T)
+		p.stats.Misses++
+		return p.factory()
 	}
+	p.stats.Hits++
 	return p.items.Pop()
 }
 
-// Restore returns an item to the pool to be reused.
+// Restore returns an item to the pool to be reused. If a Reset function
+// has been configured, it is called on the item. If the pool is at its
+// maximum size, the item is dropped instead.
 func (p *Pool[T]) Restore(v *T) {
+	if p.isFull() {
+		p.stats.Drops++
+		return
+	}
+	if p.reset != nil {
+		p.reset(v)
+	}
 	p.items.Push(v)
 }
+
+// Prefill creates new items until there are at least n items available for
+// reuse, without exceeding the maximum size of the pool.
+func (p *Pool[T]) Prefill(n int) {
+	for p.items.Size() < n && !p.isFull() {
+		p.items.Push(p.factory())
+	}
+}
+
+// Stats returns usage statistics of this pool.
+func (p *Pool[T]) Stats() PoolStats {
+	return p.stats
+}
+
+func (p *Pool[T]) isFull() bool {
+	return p.maxSize > 0 && p.items.Size() >= p.maxSize
+}
@@ -1,6 +1,8 @@
 package ds_test
 
 import (
+	"math"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -71,4 +73,80 @@ var _ = Describe("Pool", func() {
 			})
 		})
 	})
+
+	It("reports statistics", func() {
+		item := pool.Fetch()
+		pool.Restore(item)
+		pool.Fetch()
+		pool.Fetch()
+		Expect(pool.Stats()).To(Equal(ds.PoolStats{
+			Hits:   1,
+			Misses: 2,
+		}))
+	})
+
+	When("configured with options", func() {
+		var created int
+
+		BeforeEach(func() {
+			created = 0
+			pool = ds.NewPoolWith(ds.PoolOptions[Item]{
+				Factory: func() *Item {
+					created++
+					return &Item{Value: "New"}
+				},
+				Reset: func(item *Item) {
+					item.Value = "Reset"
+				},
+				MaxSize: 2,
+			})
+		})
+
+		It("uses the factory to create items", func() {
+			item := pool.Fetch()
+			Expect(item.Value).To(Equal("New"))
+			Expect(created).To(Equal(1))
+		})
+
+		It("resets restored items", func() {
+			item := pool.Fetch()
+			item.Value = "Changed"
+			pool.Restore(item)
+			Expect(pool.Fetch().Value).To(Equal("Reset"))
+		})
+
+		It("drops items beyond the maximum size", func() {
+			pool.Restore(&Item{})
+			pool.Restore(&Item{})
+			pool.Restore(&Item{})
+			Expect(pool.Size()).To(Equal(2))
+			Expect(pool.Stats().Drops).To(Equal(1))
+		})
+
+		It("prefills up to the maximum size", func() {
+			pool.Prefill(5)
+			Expect(pool.Size()).To(Equal(2))
+			Expect(created).To(Equal(2))
+			pool.Fetch()
+			Expect(pool.Stats()).To(Equal(ds.PoolStats{
+				Hits: 1,
+			}))
+		})
+	})
+
+	It("does not allocate up to the maximum size", func() {
+		pool = ds.NewPoolWith(ds.PoolOptions[Item]{
+			MaxSize: math.MaxInt,
+		})
+		Expect(pool.IsEmpty()).To(BeTrue())
+		pool.Restore(&Item{})
+		Expect(pool.Size()).To(Equal(1))
+	})
+
+	It("prefills the requested number of items", func() {
+		pool.Prefill(3)
+		Expect(pool.Size()).To(Equal(3))
+		pool.Prefill(2)
+		Expect(pool.Size()).To(Equal(3))
+	})
 })
@@ -0,0 +1,92 @@
+package ds
+
+import "iter"
+
+// NewQueue creates a new Queue instance with the specified initial capacity,
+// which only serves to preallocate memory. Exceeding the initial capacity is
+// allowed.
+func NewQueue[T any](initCapacity int) *Queue[T] {
+	return &Queue[T]{
+		items: Deque[T]{
+			items: make([]T, initCapacity),
+		},
+	}
+}
+
+// Queue is an implementation of a queue data structure. The first inserted
+// item is the first one to be removed (FIFO - first in, first out).
+//
+// The Queue is backed by a ring buffer, which means that no memory
+// allocations occur as long as the size stays within the capacity.
+//
+// The zero value of a Queue is an empty queue that is ready to use.
+type Queue[T any] struct {
+	items Deque[T]
+}
+
+// Size returns the number of items stored in this Queue.
+func (q *Queue[T]) Size() int {
+	return q.items.Size()
+}
+
+// IsEmpty returns true if there are no more items in this Queue.
+func (q *Queue[T]) IsEmpty() bool {
+	return q.items.IsEmpty()
+}
+
+// Push adds an item to the back of this Queue.
+func (q *Queue[T]) Push(v T) {
+	q.items.PushBack(v)
+}
+
+// Pop removes the item from the front of this Queue and returns it.
+// This function panics if there are no more items. Use IsEmpty to check
+// for that.
+func (q *Queue[T]) Pop() T {
+	return q.items.PopFront()
+}
+
+// Peek returns the item that is at the front of the Queue without removing it.
+// Make sure that the Queue is not empty, otherwise this method will panic.
+func (q *Queue[T]) Peek() T {
+	return q.items.PeekFront()
+}
+
+// All returns a sequence over the items in this Queue, starting from the
+// one that would be popped first.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.items.All()
+}
+
+// Clear removes all items from this Queue.
+func (q *Queue[T]) Clear() {
+	q.items.Clear()
+}
+
+// Clip removes unused capacity from the Queue.
+func (q *Queue[T]) Clip() {
+	q.items.Clip()
+}
+
+// MarshalJSON encodes this Queue as a JSON array, starting from the item
+// that would be popped first.
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return q.items.MarshalJSON()
+}
+
+// UnmarshalJSON decodes a JSON array into this Queue, replacing any existing
+// items. The first item in the array is the first one to be popped.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	return q.items.UnmarshalJSON(data)
+}
+
+// MarshalBinary encodes this Queue using encoding/gob.
+func (q *Queue[T]) MarshalBinary() ([]byte, error) {
+	return q.items.MarshalBinary()
+}
+
+// UnmarshalBinary decodes a Queue that was encoded with MarshalBinary,
+// replacing any existing items.
+func (q *Queue[T]) UnmarshalBinary(data []byte) error {
+	return q.items.UnmarshalBinary(data)
+}
@@ -0,0 +1,161 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("Queue", func() {
+	var (
+		queue *ds.Queue[string]
+	)
+
+	BeforeEach(func() {
+		queue = ds.NewQueue[string](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(queue.IsEmpty()).To(BeTrue())
+	})
+
+	It("has zero size by default", func() {
+		Expect(queue.Size()).To(BeZero())
+	})
+
+	It("panics when popping an empty queue", func() {
+		Expect(func() { queue.Pop() }).To(Panic())
+	})
+
+	When("items are added", func() {
+		BeforeEach(func() {
+			queue.Push("first")
+			queue.Push("second")
+			queue.Push("third")
+		})
+
+		It("is no longer empty", func() {
+			Expect(queue.IsEmpty()).To(BeFalse())
+		})
+
+		It("has the correct size", func() {
+			Expect(queue.Size()).To(Equal(3))
+		})
+
+		It("is possible to fetch the items", func() {
+			Expect(queue.Pop()).To(Equal("first"))
+			Expect(queue.Pop()).To(Equal("second"))
+			Expect(queue.Pop()).To(Equal("third"))
+		})
+
+		It("is possible to iterate the items", func() {
+			Expect(slices.Collect(queue.All())).To(Equal([]string{"first", "second", "third"}))
+			Expect(queue.Size()).To(Equal(3))
+		})
+
+		It("is possible to peek the front item", func() {
+			Expect(queue.Peek()).To(Equal("first"))
+			Expect(queue.Size()).To(Equal(3))
+		})
+
+		When("the queue is clipped", func() {
+			BeforeEach(func() {
+				queue.Clip()
+			})
+
+			It("still contains the same items", func() {
+				Expect(queue.Pop()).To(Equal("first"))
+				Expect(queue.Pop()).To(Equal("second"))
+				Expect(queue.Pop()).To(Equal("third"))
+			})
+		})
+
+		When("the queue is cleared", func() {
+			BeforeEach(func() {
+				queue.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(queue.IsEmpty()).To(BeTrue())
+			})
+
+			It("changes its size to zero", func() {
+				Expect(queue.Size()).To(BeZero())
+			})
+		})
+
+		When("items are popped and pushed past the capacity", func() {
+			BeforeEach(func() {
+				queue.Pop()
+				queue.Push("fourth")
+				queue.Push("fifth")
+				queue.Push("sixth")
+			})
+
+			It("preserves the order of the items", func() {
+				Expect(queue.Pop()).To(Equal("second"))
+				Expect(queue.Pop()).To(Equal("third"))
+				Expect(queue.Pop()).To(Equal("fourth"))
+				Expect(queue.Pop()).To(Equal("fifth"))
+				Expect(queue.Pop()).To(Equal("sixth"))
+				Expect(queue.IsEmpty()).To(BeTrue())
+			})
+		})
+	})
+
+	It("is usable as a zero value", func() {
+		var zero ds.Queue[string]
+		Expect(zero.IsEmpty()).To(BeTrue())
+		zero.Push("first")
+		zero.Push("second")
+		Expect(zero.Size()).To(Equal(2))
+		Expect(zero.Peek()).To(Equal("first"))
+		Expect(zero.Pop()).To(Equal("first"))
+		Expect(slices.Collect(zero.All())).To(Equal([]string{"second"}))
+	})
+
+	It("does not allocate while within capacity", func() {
+		queue = ds.NewQueue[string](4)
+		allocs := testing.AllocsPerRun(100, func() {
+			queue.Push("a")
+			queue.Push("b")
+			queue.Pop()
+			queue.Push("c")
+			queue.Pop()
+			queue.Pop()
+		})
+		Expect(allocs).To(BeZero())
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON", func() {
+			queue := ds.NewQueue[string](2)
+			queue.Push("a")
+			queue.Push("b")
+			queue.Pop()
+			queue.Push("c")
+			queue.Push("d")
+			data, err := json.Marshal(queue)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`["b","c","d"]`))
+			var restored ds.Queue[string]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Pop()).To(Equal("b"))
+			restored.Push("e")
+			Expect(restored.Size()).To(Equal(3))
+		})
+
+		It("round-trips through gob", func() {
+			queue := ds.NewQueue[string](0)
+			queue.Push("a")
+			var restored ds.Queue[string]
+			gobRoundTrip(queue, &restored)
+			Expect(restored.Pop()).To(Equal("a"))
+		})
+	})
+})
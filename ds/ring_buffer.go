@@ -0,0 +1,189 @@
+package ds
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+)
+
+// NewRingBuffer creates a new RingBuffer instance with the specified
+// capacity. Unlike other data structures in this package, the capacity
+// acts as an upper bound. Once the RingBuffer is full, adding a new item
+// evicts the oldest one.
+//
+// This function panics if the capacity is not positive.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("ds: ring buffer capacity must be positive")
+	}
+	return &RingBuffer[T]{
+		items: make([]T, capacity),
+	}
+}
+
+// RingBuffer is a fixed-capacity data structure that keeps the most recently
+// added items. When full, adding a new item overwrites the oldest one.
+//
+// The RingBuffer does not allocate memory after it has been constructed.
+type RingBuffer[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+// Size returns the number of items stored in this RingBuffer.
+func (b *RingBuffer[T]) Size() int {
+	return b.size
+}
+
+// Capacity returns the maximum number of items that this RingBuffer can hold.
+func (b *RingBuffer[T]) Capacity() int {
+	return len(b.items)
+}
+
+// IsEmpty returns true if there are no items in this RingBuffer.
+func (b *RingBuffer[T]) IsEmpty() bool {
+	return b.size == 0
+}
+
+// IsFull returns true if the RingBuffer has reached its capacity, in which
+// case a subsequent Push will evict the oldest item.
+func (b *RingBuffer[T]) IsFull() bool {
+	return b.size == len(b.items)
+}
+
+// Push adds an item as the newest one in this RingBuffer. If the RingBuffer
+// is full, the oldest item is evicted and returned together with true.
+// Otherwise, the zero value and false are returned.
+func (b *RingBuffer[T]) Push(v T) (T, bool) {
+	if b.size < len(b.items) {
+		b.items[b.index(b.size)] = v
+		b.size++
+		var zero T
+		return zero, false
+	}
+	evicted := b.items[b.head]
+	b.items[b.head] = v
+	b.head = b.index(1)
+	return evicted, true
+}
+
+// Get returns the item at the specified index, where zero is the oldest item
+// and Size()-1 is the newest one.
+//
+// This method panics if the index is outside the RingBuffer bounds.
+func (b *RingBuffer[T]) Get(index int) T {
+	if index < 0 || index >= b.size {
+		panic("ds: ring buffer index out of range")
+	}
+	return b.items[b.index(index)]
+}
+
+// Oldest returns the item that was added the earliest and is still stored.
+// This method panics if the RingBuffer is empty.
+func (b *RingBuffer[T]) Oldest() T {
+	return b.Get(0)
+}
+
+// Newest returns the item that was added the latest.
+// This method panics if the RingBuffer is empty.
+func (b *RingBuffer[T]) Newest() T {
+	return b.Get(b.size - 1)
+}
+
+// All returns a sequence over the items in this RingBuffer, starting from
+// the oldest and ending with the newest one.
+func (b *RingBuffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range b.size {
+			if !yield(b.items[b.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Indexed returns a sequence over the items in this RingBuffer, paired with
+// their indices as used by Get.
+func (b *RingBuffer[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range b.size {
+			if !yield(i, b.items[b.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all items from this RingBuffer.
+func (b *RingBuffer[T]) Clear() {
+	clear(b.items)
+	b.head = 0
+	b.size = 0
+}
+
+// MarshalJSON encodes this RingBuffer as a JSON object that holds the
+// capacity and the items from the oldest to the newest one.
+func (b *RingBuffer[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.encoded())
+}
+
+// UnmarshalJSON decodes a JSON object that was produced by MarshalJSON into
+// this RingBuffer. Existing items are removed and the capacity is replaced.
+func (b *RingBuffer[T]) UnmarshalJSON(data []byte) error {
+	var encoded ringBufferEncoding[T]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return b.replace(encoded)
+}
+
+// MarshalBinary encodes this RingBuffer using encoding/gob.
+func (b *RingBuffer[T]) MarshalBinary() ([]byte, error) {
+	return marshalGob(b.encoded())
+}
+
+// UnmarshalBinary decodes a RingBuffer that was encoded with MarshalBinary.
+// Existing items are removed and the capacity is replaced.
+func (b *RingBuffer[T]) UnmarshalBinary(data []byte) error {
+	var encoded ringBufferEncoding[T]
+	if err := unmarshalGob(data, &encoded); err != nil {
+		return err
+	}
+	return b.replace(encoded)
+}
+
+func (b *RingBuffer[T]) encoded() ringBufferEncoding[T] {
+	items := make([]T, 0, b.size)
+	for item := range b.All() {
+		items = append(items, item)
+	}
+	return ringBufferEncoding[T]{
+		Capacity: len(b.items),
+		Items:    items,
+	}
+}
+
+// replace resets this RingBuffer to the encoded state. As with
+// NewRingBuffer, the capacity needs to be positive.
+func (b *RingBuffer[T]) replace(encoded ringBufferEncoding[T]) error {
+	if encoded.Capacity <= 0 {
+		return errors.New("ring buffer capacity must be positive")
+	}
+	if len(encoded.Items) > encoded.Capacity {
+		return errors.New("ring buffer items exceed capacity")
+	}
+	b.items = make([]T, encoded.Capacity)
+	b.head = 0
+	b.size = copy(b.items, encoded.Items)
+	return nil
+}
+
+type ringBufferEncoding[T any] struct {
+	Capacity int `json:"capacity"`
+	Items    []T `json:"items"`
+}
+
+func (b *RingBuffer[T]) index(offset int) int {
+	return (b.head + offset) % len(b.items)
+}
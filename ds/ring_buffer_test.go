@@ -0,0 +1,166 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("RingBuffer", func() {
+	var (
+		buffer *ds.RingBuffer[int]
+	)
+
+	BeforeEach(func() {
+		buffer = ds.NewRingBuffer[int](3)
+	})
+
+	It("panics when created with non-positive capacity", func() {
+		Expect(func() { ds.NewRingBuffer[int](0) }).To(Panic())
+	})
+
+	It("is empty by default", func() {
+		Expect(buffer.IsEmpty()).To(BeTrue())
+		Expect(buffer.IsFull()).To(BeFalse())
+	})
+
+	It("has zero size by default", func() {
+		Expect(buffer.Size()).To(BeZero())
+	})
+
+	It("has the configured capacity", func() {
+		Expect(buffer.Capacity()).To(Equal(3))
+	})
+
+	It("panics when accessing an empty buffer", func() {
+		Expect(func() { buffer.Oldest() }).To(Panic())
+		Expect(func() { buffer.Newest() }).To(Panic())
+	})
+
+	When("items are added within capacity", func() {
+		BeforeEach(func() {
+			_, evicted := buffer.Push(1)
+			Expect(evicted).To(BeFalse())
+			_, evicted = buffer.Push(2)
+			Expect(evicted).To(BeFalse())
+		})
+
+		It("has the correct size", func() {
+			Expect(buffer.Size()).To(Equal(2))
+			Expect(buffer.IsFull()).To(BeFalse())
+		})
+
+		It("is possible to get items by index", func() {
+			Expect(buffer.Get(0)).To(Equal(1))
+			Expect(buffer.Get(1)).To(Equal(2))
+			Expect(func() { buffer.Get(2) }).To(Panic())
+		})
+
+		It("is possible to get the oldest and newest items", func() {
+			Expect(buffer.Oldest()).To(Equal(1))
+			Expect(buffer.Newest()).To(Equal(2))
+		})
+
+		It("is possible to iterate the items", func() {
+			Expect(slices.Collect(buffer.All())).To(Equal([]int{1, 2}))
+			for index, item := range buffer.Indexed() {
+				Expect(item).To(Equal(buffer.Get(index)))
+			}
+		})
+	})
+
+	When("items are added past capacity", func() {
+		var evictedValues []int
+
+		BeforeEach(func() {
+			evictedValues = nil
+			for i := 1; i <= 5; i++ {
+				if value, ok := buffer.Push(i); ok {
+					evictedValues = append(evictedValues, value)
+				}
+			}
+		})
+
+		It("returns the evicted items", func() {
+			Expect(evictedValues).To(Equal([]int{1, 2}))
+		})
+
+		It("is full", func() {
+			Expect(buffer.IsFull()).To(BeTrue())
+			Expect(buffer.Size()).To(Equal(3))
+		})
+
+		It("keeps only the most recent items", func() {
+			Expect(buffer.Oldest()).To(Equal(3))
+			Expect(buffer.Newest()).To(Equal(5))
+			Expect(slices.Collect(buffer.All())).To(Equal([]int{3, 4, 5}))
+		})
+
+		When("the buffer is cleared", func() {
+			BeforeEach(func() {
+				buffer.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(buffer.IsEmpty()).To(BeTrue())
+				Expect(buffer.Size()).To(BeZero())
+				Expect(slices.Collect(buffer.All())).To(BeEmpty())
+			})
+		})
+	})
+
+	It("does not allocate after construction", func() {
+		allocs := testing.AllocsPerRun(100, func() {
+			for i := range 10 {
+				buffer.Push(i)
+			}
+			for range buffer.All() {
+			}
+		})
+		Expect(allocs).To(BeZero())
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON from oldest to newest", func() {
+			buffer := ds.NewRingBuffer[int](3)
+			for i := range 5 {
+				buffer.Push(i)
+			}
+			data, err := json.Marshal(buffer)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"capacity":3,"items":[2,3,4]}`))
+			var restored ds.RingBuffer[int]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Capacity()).To(Equal(3))
+			Expect(restored.Oldest()).To(Equal(2))
+			evicted, ok := restored.Push(5)
+			Expect(ok).To(BeTrue())
+			Expect(evicted).To(Equal(2))
+		})
+
+		It("fails to unmarshal more items than the capacity", func() {
+			var buffer ds.RingBuffer[int]
+			Expect(json.Unmarshal([]byte(`{"capacity":1,"items":[1,2]}`), &buffer)).ToNot(Succeed())
+		})
+
+		It("fails to unmarshal a capacity that is not positive", func() {
+			var buffer ds.RingBuffer[int]
+			Expect(json.Unmarshal([]byte(`{"capacity":0,"items":[]}`), &buffer)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`{"capacity":-1,"items":[]}`), &buffer)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			source := ds.NewRingBuffer[string](2)
+			source.Push("a")
+			var restored ds.RingBuffer[string]
+			gobRoundTrip(source, &restored)
+			Expect(restored.Capacity()).To(Equal(2))
+			Expect(restored.Newest()).To(Equal("a"))
+		})
+	})
+})
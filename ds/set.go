@@ -1,6 +1,9 @@
 package ds
 
-import "maps"
+import (
+	"iter"
+	"maps"
+)
 
 // NewSet creates a new Set instance with the specified initial capacity,
 // which is only used to preallocate memory and does not act as an upper bound.
@@ -20,6 +23,16 @@ func SetFromSlice[T comparable](slice []T) *Set[T] {
 	return result
 }
 
+// SetFromSeq creates a new Set instance based on the items of the provided
+// sequence.
+func SetFromSeq[T comparable](src iter.Seq[T]) *Set[T] {
+	result := NewSet[T](0)
+	for item := range src {
+		result.items[item] = struct{}{}
+	}
+	return result
+}
+
 // SetFromMapKeys creates a new Set instance based on the keys of the
 // provided map.
 func SetFromMapKeys[T comparable, V any](m map[T]V) *Set[T] {
@@ -181,6 +194,14 @@ func (s *Set[T]) Items() []T {
 	return result
 }
 
+// All returns a sequence over all items in this Set.
+//
+// Note: The items are returned in a random order which can differ
+// between subsequent calls.
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.items)
+}
+
 // Equals return whether this set is equal to the provided set.
 func (s *Set[T]) Equals(other *Set[T]) bool {
 	return maps.Equal(s.items, other.items)
@@ -197,3 +218,53 @@ func (s *Set[T]) Clear() {
 func (s *Set[T]) Clip() {
 	s.items = maps.Clone(s.items)
 }
+
+// MarshalJSON encodes this Set as a JSON array. If the items are of an
+// integer, float or string type, they are sorted in ascending order so that
+// the output is deterministic.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	items := s.Items()
+	sortIfOrdered(items)
+	return marshalItemsJSON(items)
+}
+
+// UnmarshalJSON decodes a JSON array into this Set. Existing items are
+// removed beforehand and duplicate items in the array are ignored.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	items, err := unmarshalItemsJSON[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(items)
+	return nil
+}
+
+// MarshalBinary encodes this Set using encoding/gob. If the items are of an
+// integer, float or string type, they are sorted in ascending order so that
+// the output is deterministic.
+func (s *Set[T]) MarshalBinary() ([]byte, error) {
+	items := s.Items()
+	sortIfOrdered(items)
+	return marshalItemsBinary(items)
+}
+
+// UnmarshalBinary decodes a Set that was encoded with MarshalBinary.
+// Existing items are removed beforehand.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalItemsBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(items)
+	return nil
+}
+
+func (s *Set[T]) replace(items []T) {
+	if s.items == nil {
+		s.items = make(map[T]struct{}, len(items))
+	}
+	clear(s.items)
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+}
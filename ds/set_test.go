@@ -1,6 +1,9 @@
 package ds_test
 
 import (
+	"encoding/json"
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -67,6 +70,10 @@ var _ = Describe("Set", func() {
 			Expect(set.Items()).To(ContainElements("first", "second", "third"))
 		})
 
+		It("is possible to iterate the items", func() {
+			Expect(slices.Collect(set.All())).To(ConsistOf("first", "second", "third"))
+		})
+
 		It("is possible to check if an item is contained", func() {
 			Expect(set.Contains("first")).To(BeTrue())
 			Expect(set.Contains("missing")).To(BeFalse())
@@ -235,6 +242,16 @@ var _ = Describe("Set", func() {
 		})
 	})
 
+	When("constructed from a sequence", func() {
+		BeforeEach(func() {
+			set = ds.SetFromSeq(slices.Values([]string{"a", "b", "a", "c"}))
+		})
+
+		It("contains the items of the sequence", func() {
+			Expect(set.Items()).To(ConsistOf("a", "b", "c"))
+		})
+	})
+
 	When("constructed from a map's keys", func() {
 		BeforeEach(func() {
 			set = ds.SetFromMapKeys(map[string]int{
@@ -388,4 +405,67 @@ var _ = Describe("Set", func() {
 			Expect(set.Contains("c")).To(BeTrue())
 		})
 	})
+
+	Describe("marshaling", func() {
+		It("marshals to a sorted JSON array", func() {
+			set := ds.SetFromSlice([]int{5, 1, 3, 2})
+			data, err := json.Marshal(set)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[1,2,3,5]`))
+		})
+
+		It("marshals named string types in sorted order", func() {
+			type name string
+			set := ds.SetFromSlice([]name{"c", "a", "b"})
+			data, err := json.Marshal(set)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`["a","b","c"]`))
+		})
+
+		It("marshals an empty set to an empty array", func() {
+			data, err := json.Marshal(ds.NewSet[int](0))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[]`))
+		})
+
+		It("unmarshals into a zero value", func() {
+			var set ds.Set[string]
+			Expect(json.Unmarshal([]byte(`["a","b","a"]`), &set)).To(Succeed())
+			Expect(set.Items()).To(ConsistOf("a", "b"))
+		})
+
+		It("replaces existing items when unmarshaling", func() {
+			set := ds.SetFromSlice([]string{"x"})
+			Expect(json.Unmarshal([]byte(`["a"]`), set)).To(Succeed())
+			Expect(set.Items()).To(ConsistOf("a"))
+		})
+
+		It("fails to unmarshal non-array JSON", func() {
+			set := ds.NewSet[string](0)
+			Expect(json.Unmarshal([]byte(`{}`), set)).ToNot(Succeed())
+		})
+
+		It("round-trips through binary encoding", func() {
+			set := ds.SetFromSlice([]string{"a", "b"})
+			data, err := set.MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			restored := ds.NewSet[string](0)
+			Expect(restored.UnmarshalBinary(data)).To(Succeed())
+			Expect(restored.Equals(set)).To(BeTrue())
+		})
+
+		It("produces deterministic binary encoding", func() {
+			first, err := ds.SetFromSlice([]int{1, 2, 3, 4, 5}).MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			second, err := ds.SetFromSlice([]int{5, 4, 3, 2, 1}).MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first).To(Equal(second))
+		})
+
+		It("round-trips through gob", func() {
+			var restored ds.Set[int]
+			gobRoundTrip(ds.SetFromSlice([]int{1, 2}), &restored)
+			Expect(restored.Items()).To(ConsistOf(1, 2))
+		})
+	})
 })
@@ -0,0 +1,286 @@
+package ds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SlotHandle is a stable reference to a value stored in a SlotMap. It
+// consists of a slot index and a generation, which allows the SlotMap to
+// detect handles that refer to removed values.
+//
+// The zero SlotHandle is never valid.
+type SlotHandle struct {
+	index      uint32
+	generation uint32
+}
+
+// MarshalText encodes this SlotHandle as text in the form
+// "index:generation", which allows it to be stored alongside an encoded
+// SlotMap or used as a JSON object key.
+func (h SlotHandle) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d:%d", h.index, h.generation), nil
+}
+
+// UnmarshalText decodes a SlotHandle that was encoded with MarshalText.
+func (h *SlotHandle) UnmarshalText(text []byte) error {
+	indexText, generationText, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return fmt.Errorf("slot handle %q is missing a generation", text)
+	}
+	index, err := strconv.ParseUint(indexText, 10, 32)
+	if err != nil {
+		return fmt.Errorf("error parsing slot handle index: %w", err)
+	}
+	generation, err := strconv.ParseUint(generationText, 10, 32)
+	if err != nil {
+		return fmt.Errorf("error parsing slot handle generation: %w", err)
+	}
+	h.index = uint32(index)
+	h.generation = uint32(generation)
+	return nil
+}
+
+// NewSlotMap creates a new SlotMap instance with the specified initial
+// capacity, which is only used to preallocate memory and does not act as an
+// upper bound.
+func NewSlotMap[T any](initialCapacity int) *SlotMap[T] {
+	return &SlotMap[T]{
+		slots:     make([]slotMapSlot, 0, initialCapacity),
+		freeSlots: NewStack[uint32](0),
+		values:    make([]T, 0, initialCapacity),
+		owners:    make([]uint32, 0, initialCapacity),
+	}
+}
+
+// SlotMap is a generational arena that stores values and hands out stable
+// SlotHandles to them. SlotHandles remain valid until the value is removed,
+// after which they are rejected, even if the slot has been reused by another
+// value. All operations are performed in O(1) time.
+//
+// Values are kept in dense storage without gaps, which makes iteration
+// cache-friendly. As a consequence, pointers returned by Get and All are
+// only valid until the next Insert or Remove.
+type SlotMap[T any] struct {
+	slots     []slotMapSlot
+	freeSlots *Stack[uint32]
+	values    []T
+	owners    []uint32 // slot index of each dense value
+}
+
+type slotMapSlot struct {
+	denseIndex int
+	generation uint32 // odd when occupied, even when free
+}
+
+// IsEmpty returns true if there are no values in this SlotMap.
+func (m *SlotMap[T]) IsEmpty() bool {
+	return len(m.values) == 0
+}
+
+// Size returns the number of values stored in this SlotMap.
+func (m *SlotMap[T]) Size() int {
+	return len(m.values)
+}
+
+// Insert adds the specified value to this SlotMap and returns a SlotHandle
+// to it.
+func (m *SlotMap[T]) Insert(value T) SlotHandle {
+	var index uint32
+	if m.freeSlots.IsEmpty() {
+		index = uint32(len(m.slots))
+		m.slots = append(m.slots, slotMapSlot{})
+	} else {
+		index = m.freeSlots.Pop()
+	}
+	slot := &m.slots[index]
+	slot.generation++
+	slot.denseIndex = len(m.values)
+	m.values = append(m.values, value)
+	m.owners = append(m.owners, index)
+	return SlotHandle{
+		index:      index,
+		generation: slot.generation,
+	}
+}
+
+// Get returns a pointer to the value referenced by the specified SlotHandle.
+// The second return value is false if the SlotHandle is stale or invalid.
+//
+// The returned pointer is only valid until the next Insert or Remove.
+func (m *SlotMap[T]) Get(handle SlotHandle) (*T, bool) {
+	slot, ok := m.slot(handle)
+	if !ok {
+		return nil, false
+	}
+	return &m.values[slot.denseIndex], true
+}
+
+// Contains returns whether the specified SlotHandle references a value in
+// this SlotMap.
+func (m *SlotMap[T]) Contains(handle SlotHandle) bool {
+	_, ok := m.slot(handle)
+	return ok
+}
+
+// Remove removes the value referenced by the specified SlotHandle and frees
+// its slot. This method returns true if the SlotHandle was valid and false
+// otherwise.
+func (m *SlotMap[T]) Remove(handle SlotHandle) bool {
+	slot, ok := m.slot(handle)
+	if !ok {
+		return false
+	}
+	denseIndex := slot.denseIndex
+	lastIndex := len(m.values) - 1
+	if denseIndex != lastIndex {
+		m.values[denseIndex] = m.values[lastIndex]
+		m.owners[denseIndex] = m.owners[lastIndex]
+		m.slots[m.owners[denseIndex]].denseIndex = denseIndex
+	}
+	var zero T
+	m.values[lastIndex] = zero
+	m.values = m.values[:lastIndex]
+	m.owners = m.owners[:lastIndex]
+	slot.generation++
+	m.freeSlots.Push(handle.index)
+	return true
+}
+
+// All returns a sequence over the SlotHandles and values stored in this
+// SlotMap. The values are visited in dense storage order, which is
+// unrelated to the order of insertion.
+//
+// The SlotMap should not be modified during iteration.
+func (m *SlotMap[T]) All() iter.Seq2[SlotHandle, *T] {
+	return func(yield func(SlotHandle, *T) bool) {
+		for i := range m.values {
+			index := m.owners[i]
+			handle := SlotHandle{
+				index:      index,
+				generation: m.slots[index].generation,
+			}
+			if !yield(handle, &m.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all values from this SlotMap. All previously issued
+// SlotHandles become stale.
+func (m *SlotMap[T]) Clear() {
+	for _, index := range m.owners {
+		m.slots[index].generation++
+		m.freeSlots.Push(index)
+	}
+	clear(m.values)
+	m.values = m.values[:0]
+	m.owners = m.owners[:0]
+}
+
+// MarshalJSON encodes this SlotMap as a JSON object that holds the
+// generation of each slot, as well as the values in dense order together
+// with the slots they occupy. This allows SlotHandles that were issued
+// before encoding to remain valid after decoding.
+func (m *SlotMap[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.encoded())
+}
+
+// UnmarshalJSON decodes a JSON object that was produced by MarshalJSON into
+// this SlotMap. Existing values are removed beforehand.
+func (m *SlotMap[T]) UnmarshalJSON(data []byte) error {
+	var encoded slotMapEncoding[T]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return m.replace(encoded)
+}
+
+// MarshalBinary encodes this SlotMap using encoding/gob. As with
+// MarshalJSON, SlotHandles remain valid after decoding.
+func (m *SlotMap[T]) MarshalBinary() ([]byte, error) {
+	return marshalGob(m.encoded())
+}
+
+// UnmarshalBinary decodes a SlotMap that was encoded with MarshalBinary.
+// Existing values are removed beforehand.
+func (m *SlotMap[T]) UnmarshalBinary(data []byte) error {
+	var encoded slotMapEncoding[T]
+	if err := unmarshalGob(data, &encoded); err != nil {
+		return err
+	}
+	return m.replace(encoded)
+}
+
+func (m *SlotMap[T]) encoded() slotMapEncoding[T] {
+	generations := make([]uint32, len(m.slots))
+	for i, slot := range m.slots {
+		generations[i] = slot.generation
+	}
+	return slotMapEncoding[T]{
+		Generations: generations,
+		Owners:      append(make([]uint32, 0, len(m.owners)), m.owners...),
+		Values:      append(make([]T, 0, len(m.values)), m.values...),
+	}
+}
+
+// replace validates the encoded state and restores it. Free slots are
+// reused starting from the lowest index.
+func (m *SlotMap[T]) replace(encoded slotMapEncoding[T]) error {
+	if len(encoded.Owners) != len(encoded.Values) {
+		return errors.New("slot map owners do not match values")
+	}
+	slots := make([]slotMapSlot, len(encoded.Generations))
+	occupied := 0
+	for i, generation := range encoded.Generations {
+		slots[i].generation = generation
+		if generation%2 == 1 {
+			occupied++
+		}
+	}
+	if occupied != len(encoded.Owners) {
+		return errors.New("slot map occupied slots do not match values")
+	}
+	seen := make([]bool, len(slots))
+	for denseIndex, index := range encoded.Owners {
+		if int(index) >= len(slots) || slots[index].generation%2 == 0 || seen[index] {
+			return fmt.Errorf("slot map owner %d is invalid", index)
+		}
+		seen[index] = true
+		slots[index].denseIndex = denseIndex
+	}
+	freeSlots := NewStack[uint32](len(slots) - occupied)
+	for i := len(slots) - 1; i >= 0; i-- {
+		if slots[i].generation%2 == 0 {
+			freeSlots.Push(uint32(i))
+		}
+	}
+	m.slots = slots
+	m.freeSlots = freeSlots
+	m.values = slices.Clone(encoded.Values)
+	m.owners = slices.Clone(encoded.Owners)
+	return nil
+}
+
+type slotMapEncoding[T any] struct {
+	Generations []uint32 `json:"generations"`
+	Owners      []uint32 `json:"owners"`
+	Values      []T      `json:"values"`
+}
+
+func (m *SlotMap[T]) slot(handle SlotHandle) (*slotMapSlot, bool) {
+	if int(handle.index) >= len(m.slots) {
+		return nil, false
+	}
+	slot := &m.slots[handle.index]
+	if slot.generation != handle.generation || slot.generation%2 == 0 {
+		return nil, false
+	}
+	return slot, true
+}
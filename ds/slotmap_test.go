@@ -0,0 +1,219 @@
+package ds_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("SlotMap", func() {
+	var slotMap *ds.SlotMap[string]
+
+	valuesOf := func() []string {
+		var values []string
+		for _, value := range slotMap.All() {
+			values = append(values, *value)
+		}
+		return values
+	}
+
+	BeforeEach(func() {
+		slotMap = ds.NewSlotMap[string](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(slotMap.IsEmpty()).To(BeTrue())
+		Expect(slotMap.Size()).To(BeZero())
+	})
+
+	It("rejects the zero handle", func() {
+		slotMap.Insert("value")
+		Expect(slotMap.Contains(ds.SlotHandle{})).To(BeFalse())
+		_, ok := slotMap.Get(ds.SlotHandle{})
+		Expect(ok).To(BeFalse())
+	})
+
+	When("values are inserted", func() {
+		var first, second, third ds.SlotHandle
+
+		BeforeEach(func() {
+			first = slotMap.Insert("first")
+			second = slotMap.Insert("second")
+			third = slotMap.Insert("third")
+		})
+
+		It("has the correct size", func() {
+			Expect(slotMap.IsEmpty()).To(BeFalse())
+			Expect(slotMap.Size()).To(Equal(3))
+		})
+
+		It("returns distinct handles", func() {
+			Expect(first).ToNot(Equal(second))
+			Expect(second).ToNot(Equal(third))
+		})
+
+		It("is possible to get the values", func() {
+			value, ok := slotMap.Get(second)
+			Expect(ok).To(BeTrue())
+			Expect(*value).To(Equal("second"))
+		})
+
+		It("is possible to modify values in place", func() {
+			value, _ := slotMap.Get(first)
+			*value = "modified"
+			value, _ = slotMap.Get(first)
+			Expect(*value).To(Equal("modified"))
+		})
+
+		It("iterates all values", func() {
+			Expect(valuesOf()).To(Equal([]string{"first", "second", "third"}))
+			for handle, value := range slotMap.All() {
+				stored, ok := slotMap.Get(handle)
+				Expect(ok).To(BeTrue())
+				Expect(stored).To(BeIdenticalTo(value))
+			}
+		})
+
+		When("a value is removed", func() {
+			BeforeEach(func() {
+				Expect(slotMap.Remove(first)).To(BeTrue())
+			})
+
+			It("reduces the size", func() {
+				Expect(slotMap.Size()).To(Equal(2))
+			})
+
+			It("rejects the stale handle", func() {
+				Expect(slotMap.Contains(first)).To(BeFalse())
+				_, ok := slotMap.Get(first)
+				Expect(ok).To(BeFalse())
+				Expect(slotMap.Remove(first)).To(BeFalse())
+			})
+
+			It("keeps the other handles valid", func() {
+				value, ok := slotMap.Get(third)
+				Expect(ok).To(BeTrue())
+				Expect(*value).To(Equal("third"))
+				value, ok = slotMap.Get(second)
+				Expect(ok).To(BeTrue())
+				Expect(*value).To(Equal("second"))
+			})
+
+			It("keeps the storage dense", func() {
+				Expect(valuesOf()).To(ConsistOf("second", "third"))
+			})
+
+			It("rejects the stale handle after the slot is reused", func() {
+				fourth := slotMap.Insert("fourth")
+				Expect(slotMap.Contains(first)).To(BeFalse())
+				value, ok := slotMap.Get(fourth)
+				Expect(ok).To(BeTrue())
+				Expect(*value).To(Equal("fourth"))
+			})
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				slotMap.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(slotMap.IsEmpty()).To(BeTrue())
+				Expect(valuesOf()).To(BeEmpty())
+			})
+
+			It("rejects all previous handles", func() {
+				Expect(slotMap.Contains(first)).To(BeFalse())
+				Expect(slotMap.Contains(second)).To(BeFalse())
+				Expect(slotMap.Contains(third)).To(BeFalse())
+				handle := slotMap.Insert("new")
+				Expect(slotMap.Contains(handle)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		var (
+			slotMap             *ds.SlotMap[string]
+			first, second       ds.SlotHandle
+			removed, reinserted ds.SlotHandle
+		)
+
+		BeforeEach(func() {
+			slotMap = ds.NewSlotMap[string](0)
+			first = slotMap.Insert("a")
+			removed = slotMap.Insert("b")
+			second = slotMap.Insert("c")
+			slotMap.Remove(removed)
+			reinserted = slotMap.Insert("d")
+			slotMap.Remove(reinserted)
+		})
+
+		checkRestored := func(restored *ds.SlotMap[string]) {
+			Expect(restored.Size()).To(Equal(2))
+			value, ok := restored.Get(first)
+			Expect(ok).To(BeTrue())
+			Expect(*value).To(Equal("a"))
+			value, ok = restored.Get(second)
+			Expect(ok).To(BeTrue())
+			Expect(*value).To(Equal("c"))
+			Expect(restored.Contains(removed)).To(BeFalse())
+			Expect(restored.Contains(reinserted)).To(BeFalse())
+			handle := restored.Insert("e")
+			Expect(restored.Contains(handle)).To(BeTrue())
+			Expect(handle).ToNot(Equal(removed))
+			Expect(handle).ToNot(Equal(reinserted))
+			Expect(restored.Contains(first)).To(BeTrue())
+		}
+
+		It("keeps handles valid through JSON", func() {
+			data, err := json.Marshal(slotMap)
+			Expect(err).ToNot(HaveOccurred())
+			var restored ds.SlotMap[string]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			checkRestored(&restored)
+		})
+
+		It("keeps handles valid through gob", func() {
+			var restored ds.SlotMap[string]
+			gobRoundTrip(slotMap, &restored)
+			checkRestored(&restored)
+		})
+
+		It("unmarshals into a zero value that can be used", func() {
+			var restored ds.SlotMap[string]
+			Expect(json.Unmarshal([]byte(`{"generations":[],"owners":[],"values":[]}`), &restored)).To(Succeed())
+			handle := restored.Insert("x")
+			Expect(restored.Contains(handle)).To(BeTrue())
+		})
+
+		It("fails to unmarshal inconsistent state", func() {
+			var restored ds.SlotMap[string]
+			Expect(json.Unmarshal([]byte(`{"generations":[1],"owners":[],"values":[]}`), &restored)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`{"generations":[2],"owners":[0],"values":["x"]}`), &restored)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`{"generations":[1],"owners":[0],"values":[]}`), &restored)).ToNot(Succeed())
+		})
+
+		It("round-trips handles through text encoding", func() {
+			text, err := second.MarshalText()
+			Expect(err).ToNot(HaveOccurred())
+			var handle ds.SlotHandle
+			Expect(handle.UnmarshalText(text)).To(Succeed())
+			Expect(handle).To(Equal(second))
+			Expect(handle.UnmarshalText([]byte("1"))).ToNot(Succeed())
+			Expect(handle.UnmarshalText([]byte("1:x"))).ToNot(Succeed())
+		})
+
+		It("supports handles as JSON object keys", func() {
+			source := map[ds.SlotHandle]int{first: 1, second: 2}
+			data, err := json.Marshal(source)
+			Expect(err).ToNot(HaveOccurred())
+			var target map[ds.SlotHandle]int
+			Expect(json.Unmarshal(data, &target)).To(Succeed())
+			Expect(target).To(Equal(source))
+		})
+	})
+})
@@ -0,0 +1,229 @@
+package ds
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+)
+
+// NewSortedMap creates a new SortedMap instance that orders its keys
+// according to their natural ordering.
+func NewSortedMap[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return NewSortedMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewSortedMapFunc creates a new SortedMap instance that orders its keys
+// according to the specified compare function. The function should return
+// a negative number when a < b, a positive number when a > b and zero when
+// a == b, same as cmp.Compare.
+func NewSortedMapFunc[K, V any](compare func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{
+		tree: sortedTree[K, V]{
+			compare: compare,
+		},
+	}
+}
+
+// SortedMap is a map data structure that keeps its keys in sorted order.
+// It supports range queries, as well as finding the entries with the
+// minimum, maximum, floor and ceiling keys.
+//
+// The SortedMap is backed by a balanced binary search tree, which means that
+// most operations are performed in O(log n) time.
+type SortedMap[K, V any] struct {
+	tree sortedTree[K, V]
+}
+
+// IsEmpty returns true if there are no entries in this SortedMap.
+func (m *SortedMap[K, V]) IsEmpty() bool {
+	return m.tree.root == nil
+}
+
+// Size returns the number of entries stored in this SortedMap.
+func (m *SortedMap[K, V]) Size() int {
+	return m.tree.size()
+}
+
+// Set stores the specified value for the specified key, replacing any
+// previous value.
+func (m *SortedMap[K, V]) Set(key K, value V) {
+	m.tree.put(key, value)
+}
+
+// Get returns the value stored for the specified key. The second return
+// value is false if there is no such key.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	node := m.tree.find(key)
+	if node == nil {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Has returns whether this SortedMap contains the specified key.
+func (m *SortedMap[K, V]) Has(key K) bool {
+	return m.tree.find(key) != nil
+}
+
+// Delete removes the specified key from this SortedMap. This method returns
+// true if there was such a key and false otherwise.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	return m.tree.delete(key)
+}
+
+// Min returns the entry with the smallest key in this SortedMap. The last
+// return value is false if the SortedMap is empty.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	return nodeEntry(m.tree.min())
+}
+
+// Max returns the entry with the largest key in this SortedMap. The last
+// return value is false if the SortedMap is empty.
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	return nodeEntry(m.tree.max())
+}
+
+// Floor returns the entry with the largest key that is less than or equal
+// to the specified key. The last return value is false if there is no such
+// entry.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	return nodeEntry(m.tree.floor(key))
+}
+
+// Ceiling returns the entry with the smallest key that is greater than or
+// equal to the specified key. The last return value is false if there is no
+// such entry.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return nodeEntry(m.tree.ceiling(key))
+}
+
+// Rank returns the number of keys in this SortedMap that are strictly less
+// than the specified key.
+func (m *SortedMap[K, V]) Rank(key K) int {
+	return m.tree.rank(key)
+}
+
+// Select returns the entry at the specified index in sorted order (starting
+// from zero).
+//
+// This method panics if the index is outside the SortedMap bounds.
+func (m *SortedMap[K, V]) Select(index int) (K, V) {
+	node := m.tree.selectAt(index)
+	return node.key, node.value
+}
+
+// Range returns a sequence over the entries with keys between lo and hi
+// (inclusive) in sorted order.
+func (m *SortedMap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.tree.eachInRange(lo, hi, func(node *sortedNode[K, V]) bool {
+			return yield(node.key, node.value)
+		})
+	}
+}
+
+// Keys returns a sequence over the keys of this SortedMap in sorted order.
+func (m *SortedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.tree.each(func(node *sortedNode[K, V]) bool {
+			return yield(node.key)
+		})
+	}
+}
+
+// Values returns a sequence over the values of this SortedMap in the order
+// of their keys.
+func (m *SortedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.tree.each(func(node *sortedNode[K, V]) bool {
+			return yield(node.value)
+		})
+	}
+}
+
+// All returns a sequence over the entries of this SortedMap in sorted order.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.tree.each(func(node *sortedNode[K, V]) bool {
+			return yield(node.key, node.value)
+		})
+	}
+}
+
+// Clear removes all entries from this SortedMap.
+func (m *SortedMap[K, V]) Clear() {
+	m.tree.clear()
+}
+
+// MarshalJSON encodes this SortedMap as a JSON array of objects, each
+// holding a key and its value, in sorted order.
+func (m *SortedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(m.entries())
+}
+
+// UnmarshalJSON decodes a JSON array that was produced by MarshalJSON into
+// this SortedMap. Existing entries are removed beforehand.
+//
+// The keys are ordered with the compare function of this SortedMap, which
+// means that it needs to be created through NewSortedMap or
+// NewSortedMapFunc. Decoding into a zero value returns an error.
+func (m *SortedMap[K, V]) UnmarshalJSON(data []byte) error {
+	entries, err := unmarshalItemsJSON[keyValue[K, V]](data)
+	if err != nil {
+		return err
+	}
+	return m.replace(entries)
+}
+
+// MarshalBinary encodes this SortedMap using encoding/gob.
+func (m *SortedMap[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(m.entries())
+}
+
+// UnmarshalBinary decodes a SortedMap that was encoded with MarshalBinary.
+// Existing entries are removed beforehand. As with UnmarshalJSON, this
+// SortedMap needs to have a compare function.
+func (m *SortedMap[K, V]) UnmarshalBinary(data []byte) error {
+	entries, err := unmarshalItemsBinary[keyValue[K, V]](data)
+	if err != nil {
+		return err
+	}
+	return m.replace(entries)
+}
+
+func (m *SortedMap[K, V]) entries() []keyValue[K, V] {
+	result := make([]keyValue[K, V], 0, m.Size())
+	for key, value := range m.All() {
+		result = append(result, keyValue[K, V]{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return result
+}
+
+func (m *SortedMap[K, V]) replace(entries []keyValue[K, V]) error {
+	if m.tree.compare == nil {
+		return errors.New("sorted map has no compare function")
+	}
+	keys := make([]K, len(entries))
+	values := make([]V, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+		values[i] = entry.Value
+	}
+	m.tree.load(keys, values)
+	return nil
+}
+
+func nodeEntry[K, V any](node *sortedNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var (
+			zeroKey   K
+			zeroValue V
+		)
+		return zeroKey, zeroValue, false
+	}
+	return node.key, node.value, true
+}
@@ -0,0 +1,161 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("SortedMap", func() {
+	var sortedMap *ds.SortedMap[string, int]
+
+	BeforeEach(func() {
+		sortedMap = ds.NewSortedMap[string, int]()
+	})
+
+	It("is empty by default", func() {
+		Expect(sortedMap.IsEmpty()).To(BeTrue())
+		Expect(sortedMap.Size()).To(BeZero())
+	})
+
+	It("returns false for missing keys", func() {
+		_, ok := sortedMap.Get("missing")
+		Expect(ok).To(BeFalse())
+		Expect(sortedMap.Has("missing")).To(BeFalse())
+		Expect(sortedMap.Delete("missing")).To(BeFalse())
+		_, _, ok = sortedMap.Min()
+		Expect(ok).To(BeFalse())
+	})
+
+	When("entries are added", func() {
+		BeforeEach(func() {
+			sortedMap.Set("delta", 4)
+			sortedMap.Set("alpha", 1)
+			sortedMap.Set("echo", 5)
+			sortedMap.Set("bravo", 2)
+		})
+
+		It("has the correct size", func() {
+			Expect(sortedMap.Size()).To(Equal(4))
+		})
+
+		It("is possible to get the values", func() {
+			value, ok := sortedMap.Get("bravo")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(2))
+			Expect(sortedMap.Has("echo")).To(BeTrue())
+		})
+
+		It("replaces values of existing keys", func() {
+			sortedMap.Set("alpha", 10)
+			Expect(sortedMap.Size()).To(Equal(4))
+			value, _ := sortedMap.Get("alpha")
+			Expect(value).To(Equal(10))
+		})
+
+		It("iterates in key order", func() {
+			Expect(slices.Collect(sortedMap.Keys())).To(Equal([]string{"alpha", "bravo", "delta", "echo"}))
+			Expect(slices.Collect(sortedMap.Values())).To(Equal([]int{1, 2, 4, 5}))
+			var keys []string
+			for key := range sortedMap.All() {
+				keys = append(keys, key)
+			}
+			Expect(keys).To(Equal([]string{"alpha", "bravo", "delta", "echo"}))
+		})
+
+		It("returns the min and max entries", func() {
+			key, value, ok := sortedMap.Min()
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("alpha"))
+			Expect(value).To(Equal(1))
+			key, value, ok = sortedMap.Max()
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("echo"))
+			Expect(value).To(Equal(5))
+		})
+
+		It("returns the floor and ceiling entries", func() {
+			key, _, ok := sortedMap.Floor("charlie")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("bravo"))
+			key, _, ok = sortedMap.Ceiling("charlie")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("delta"))
+		})
+
+		It("returns the entries in a range", func() {
+			var keys []string
+			for key := range sortedMap.Range("b", "d~") {
+				keys = append(keys, key)
+			}
+			Expect(keys).To(Equal([]string{"bravo", "delta"}))
+		})
+
+		It("supports rank and select", func() {
+			Expect(sortedMap.Rank("delta")).To(Equal(2))
+			key, value := sortedMap.Select(2)
+			Expect(key).To(Equal("delta"))
+			Expect(value).To(Equal(4))
+		})
+
+		It("is possible to delete keys", func() {
+			Expect(sortedMap.Delete("bravo")).To(BeTrue())
+			Expect(sortedMap.Has("bravo")).To(BeFalse())
+			Expect(slices.Collect(sortedMap.Keys())).To(Equal([]string{"alpha", "delta", "echo"}))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				sortedMap.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(sortedMap.IsEmpty()).To(BeTrue())
+				Expect(slices.Collect(sortedMap.Keys())).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		BeforeEach(func() {
+			sortedMap.Set("b", 2)
+			sortedMap.Set("c", 3)
+			sortedMap.Set("a", 1)
+		})
+
+		It("round-trips through JSON in sorted order", func() {
+			data, err := json.Marshal(sortedMap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[{"key":"a","value":1},{"key":"b","value":2},{"key":"c","value":3}]`))
+
+			restored := ds.NewSortedMap[string, int]()
+			restored.Set("z", 26)
+			Expect(json.Unmarshal(data, restored)).To(Succeed())
+			Expect(slices.Collect(restored.Keys())).To(Equal([]string{"a", "b", "c"}))
+			Expect(slices.Collect(restored.Values())).To(Equal([]int{1, 2, 3}))
+		})
+
+		It("keeps the last value of repeated keys", func() {
+			data := `[{"key":"b","value":1},{"key":"a","value":2},{"key":"b","value":3}]`
+			Expect(json.Unmarshal([]byte(data), sortedMap)).To(Succeed())
+			Expect(slices.Collect(sortedMap.Keys())).To(Equal([]string{"a", "b"}))
+			Expect(slices.Collect(sortedMap.Values())).To(Equal([]int{2, 3}))
+		})
+
+		It("fails to unmarshal into a zero value", func() {
+			var zero ds.SortedMap[string, int]
+			Expect(json.Unmarshal([]byte(`[]`), &zero)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			restored := ds.NewSortedMap[string, int]()
+			gobRoundTrip(sortedMap, restored)
+			Expect(slices.Collect(restored.Keys())).To(Equal([]string{"a", "b", "c"}))
+			Expect(slices.Collect(restored.Values())).To(Equal([]int{1, 2, 3}))
+		})
+	})
+})
@@ -0,0 +1,268 @@
+package ds
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+)
+
+// NewSortedSet creates a new SortedSet instance that orders its items
+// according to their natural ordering.
+func NewSortedSet[T cmp.Ordered]() *SortedSet[T] {
+	return NewSortedSetFunc(cmp.Compare[T])
+}
+
+// NewSortedSetFunc creates a new SortedSet instance that orders its items
+// according to the specified compare function. The function should return
+// a negative number when a < b, a positive number when a > b and zero when
+// a == b, same as cmp.Compare.
+func NewSortedSetFunc[T any](compare func(a, b T) int) *SortedSet[T] {
+	return &SortedSet[T]{
+		tree: sortedTree[T, struct{}]{
+			compare: compare,
+		},
+	}
+}
+
+// SortedSetFromSlice creates a new SortedSet instance based on the elements
+// contained in the provided slice, ordered according to their natural
+// ordering.
+func SortedSetFromSlice[T cmp.Ordered](slice []T) *SortedSet[T] {
+	result := NewSortedSet[T]()
+	for _, item := range slice {
+		result.Add(item)
+	}
+	return result
+}
+
+// SortedSetUnion creates a new SortedSet that is the union of the specified
+// sets. Both sets need to use the same ordering.
+//
+// The result is built by merging the items of the two sets in O(n) time.
+func SortedSetUnion[T any](first, second *SortedSet[T]) *SortedSet[T] {
+	return mergeSortedSets(first, second, true, true, true)
+}
+
+// SortedSetDifference creates a new SortedSet that holds the difference
+// between the first and the second specified sets. Both sets need to use the
+// same ordering.
+//
+// The result is built by merging the items of the two sets in O(n) time.
+func SortedSetDifference[T any](first, second *SortedSet[T]) *SortedSet[T] {
+	return mergeSortedSets(first, second, true, false, false)
+}
+
+// SortedSetIntersection creates a new SortedSet that holds the intersection
+// of the items of the two specified sets. Both sets need to use the same
+// ordering.
+//
+// The result is built by merging the items of the two sets in O(n) time.
+func SortedSetIntersection[T any](first, second *SortedSet[T]) *SortedSet[T] {
+	return mergeSortedSets(first, second, false, true, false)
+}
+
+// SortedSet represents a set data structure that keeps its items in sorted
+// order. Unlike Set, it supports range queries, as well as finding the
+// minimum, maximum, floor and ceiling items.
+//
+// The SortedSet is backed by a balanced binary search tree, which means that
+// most operations are performed in O(log n) time.
+type SortedSet[T any] struct {
+	tree sortedTree[T, struct{}]
+}
+
+// IsEmpty returns whether this SortedSet is empty.
+func (s *SortedSet[T]) IsEmpty() bool {
+	return s.tree.root == nil
+}
+
+// Size returns the number of items contained in this SortedSet.
+func (s *SortedSet[T]) Size() int {
+	return s.tree.size()
+}
+
+// Add adds the specified item to this SortedSet if it was not present
+// already. This method returns true if the operation was performed and false
+// if the item was already present.
+func (s *SortedSet[T]) Add(item T) bool {
+	return s.tree.put(item, struct{}{})
+}
+
+// Remove removes the specified item from this SortedSet.
+// This method returns true if there was in fact such an item to be removed
+// and false otherwise.
+func (s *SortedSet[T]) Remove(item T) bool {
+	return s.tree.delete(item)
+}
+
+// Contains returns whether this SortedSet holds the specified item.
+func (s *SortedSet[T]) Contains(item T) bool {
+	return s.tree.find(item) != nil
+}
+
+// Min returns the smallest item in this SortedSet. The second return value
+// is false if the SortedSet is empty.
+func (s *SortedSet[T]) Min() (T, bool) {
+	return nodeKey(s.tree.min())
+}
+
+// Max returns the largest item in this SortedSet. The second return value
+// is false if the SortedSet is empty.
+func (s *SortedSet[T]) Max() (T, bool) {
+	return nodeKey(s.tree.max())
+}
+
+// Floor returns the largest item in this SortedSet that is less than or
+// equal to the specified item. The second return value is false if there is
+// no such item.
+func (s *SortedSet[T]) Floor(item T) (T, bool) {
+	return nodeKey(s.tree.floor(item))
+}
+
+// Ceiling returns the smallest item in this SortedSet that is greater than
+// or equal to the specified item. The second return value is false if there
+// is no such item.
+func (s *SortedSet[T]) Ceiling(item T) (T, bool) {
+	return nodeKey(s.tree.ceiling(item))
+}
+
+// Rank returns the number of items in this SortedSet that are strictly less
+// than the specified item. If the item is contained, this is its index in
+// sorted order.
+func (s *SortedSet[T]) Rank(item T) int {
+	return s.tree.rank(item)
+}
+
+// Select returns the item at the specified index in sorted order (starting
+// from zero).
+//
+// This method panics if the index is outside the SortedSet bounds.
+func (s *SortedSet[T]) Select(index int) T {
+	return s.tree.selectAt(index).key
+}
+
+// Range returns a sequence over the items that are between lo and hi
+// (inclusive) in sorted order.
+func (s *SortedSet[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.tree.eachInRange(lo, hi, func(node *sortedNode[T, struct{}]) bool {
+			return yield(node.key)
+		})
+	}
+}
+
+// All returns a sequence over all items in this SortedSet in sorted order.
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.tree.each(func(node *sortedNode[T, struct{}]) bool {
+			return yield(node.key)
+		})
+	}
+}
+
+// Items returns a slice containing all of the items from this SortedSet in
+// sorted order.
+func (s *SortedSet[T]) Items() []T {
+	result := make([]T, 0, s.Size())
+	for item := range s.All() {
+		result = append(result, item)
+	}
+	return result
+}
+
+// Clear removes all items from this SortedSet.
+func (s *SortedSet[T]) Clear() {
+	s.tree.clear()
+}
+
+// MarshalJSON encodes this SortedSet as a JSON array of its items in sorted
+// order.
+func (s *SortedSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(s.Items())
+}
+
+// UnmarshalJSON decodes a JSON array into this SortedSet. Existing items are
+// removed beforehand.
+//
+// The items are ordered with the compare function of this SortedSet, which
+// means that it needs to be created through NewSortedSet or
+// NewSortedSetFunc. Decoding into a zero value returns an error.
+func (s *SortedSet[T]) UnmarshalJSON(data []byte) error {
+	items, err := unmarshalItemsJSON[T](data)
+	if err != nil {
+		return err
+	}
+	return s.replace(items)
+}
+
+// MarshalBinary encodes this SortedSet using encoding/gob.
+func (s *SortedSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(s.Items())
+}
+
+// UnmarshalBinary decodes a SortedSet that was encoded with MarshalBinary.
+// Existing items are removed beforehand. As with UnmarshalJSON, this
+// SortedSet needs to have a compare function.
+func (s *SortedSet[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalItemsBinary[T](data)
+	if err != nil {
+		return err
+	}
+	return s.replace(items)
+}
+
+func (s *SortedSet[T]) replace(items []T) error {
+	if s.tree.compare == nil {
+		return errors.New("sorted set has no compare function")
+	}
+	s.tree.load(items, nil)
+	return nil
+}
+
+func nodeKey[K, V any](node *sortedNode[K, V]) (K, bool) {
+	if node == nil {
+		var zero K
+		return zero, false
+	}
+	return node.key, true
+}
+
+// mergeSortedSets walks the items of the two sets in sorted order and keeps
+// the ones that are only in the first set, in both sets or only in the
+// second set, depending on the specified flags.
+func mergeSortedSets[T any](first, second *SortedSet[T], onlyFirst, both, onlySecond bool) *SortedSet[T] {
+	compare := first.tree.compare
+	firstItems := first.Items()
+	secondItems := second.Items()
+	items := make([]T, 0, len(firstItems)+len(secondItems))
+	var i, j int
+	for i < len(firstItems) && j < len(secondItems) {
+		switch c := compare(firstItems[i], secondItems[j]); {
+		case c < 0:
+			if onlyFirst {
+				items = append(items, firstItems[i])
+			}
+			i++
+		case c > 0:
+			if onlySecond {
+				items = append(items, secondItems[j])
+			}
+			j++
+		default:
+			if both {
+				items = append(items, firstItems[i])
+			}
+			i++
+			j++
+		}
+	}
+	if onlyFirst {
+		items = append(items, firstItems[i:]...)
+	}
+	if onlySecond {
+		items = append(items, secondItems[j:]...)
+	}
+	result := NewSortedSetFunc(compare)
+	result.tree.build(items, nil)
+	return result
+}
@@ -0,0 +1,248 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("SortedSet", func() {
+	var set *ds.SortedSet[int]
+
+	BeforeEach(func() {
+		set = ds.NewSortedSet[int]()
+	})
+
+	It("is empty by default", func() {
+		Expect(set.IsEmpty()).To(BeTrue())
+		Expect(set.Size()).To(BeZero())
+		Expect(set.Items()).To(BeEmpty())
+	})
+
+	It("has no min or max", func() {
+		_, ok := set.Min()
+		Expect(ok).To(BeFalse())
+		_, ok = set.Max()
+		Expect(ok).To(BeFalse())
+	})
+
+	When("items are added", func() {
+		BeforeEach(func() {
+			for _, item := range []int{50, 20, 80, 10, 30, 70, 90} {
+				Expect(set.Add(item)).To(BeTrue())
+			}
+		})
+
+		It("has the correct size", func() {
+			Expect(set.Size()).To(Equal(7))
+			Expect(set.IsEmpty()).To(BeFalse())
+		})
+
+		It("does not add duplicates", func() {
+			Expect(set.Add(30)).To(BeFalse())
+			Expect(set.Size()).To(Equal(7))
+		})
+
+		It("contains the items", func() {
+			Expect(set.Contains(30)).To(BeTrue())
+			Expect(set.Contains(40)).To(BeFalse())
+		})
+
+		It("keeps the items sorted", func() {
+			Expect(set.Items()).To(Equal([]int{10, 20, 30, 50, 70, 80, 90}))
+			Expect(slices.Collect(set.All())).To(Equal([]int{10, 20, 30, 50, 70, 80, 90}))
+		})
+
+		It("returns the min and max items", func() {
+			item, ok := set.Min()
+			Expect(ok).To(BeTrue())
+			Expect(item).To(Equal(10))
+			item, ok = set.Max()
+			Expect(ok).To(BeTrue())
+			Expect(item).To(Equal(90))
+		})
+
+		It("returns the floor of an item", func() {
+			item, ok := set.Floor(55)
+			Expect(ok).To(BeTrue())
+			Expect(item).To(Equal(50))
+			item, ok = set.Floor(50)
+			Expect(ok).To(BeTrue())
+			Expect(item).To(Equal(50))
+			_, ok = set.Floor(5)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("returns the ceiling of an item", func() {
+			item, ok := set.Ceiling(55)
+			Expect(ok).To(BeTrue())
+			Expect(item).To(Equal(70))
+			item, ok = set.Ceiling(70)
+			Expect(ok).To(BeTrue())
+			Expect(item).To(Equal(70))
+			_, ok = set.Ceiling(95)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("returns the items in a range", func() {
+			Expect(slices.Collect(set.Range(20, 70))).To(Equal([]int{20, 30, 50, 70}))
+			Expect(slices.Collect(set.Range(21, 69))).To(Equal([]int{30, 50}))
+			Expect(slices.Collect(set.Range(91, 100))).To(BeEmpty())
+		})
+
+		It("stops range iteration early", func() {
+			var items []int
+			for item := range set.Range(0, 100) {
+				if item > 30 {
+					break
+				}
+				items = append(items, item)
+			}
+			Expect(items).To(Equal([]int{10, 20, 30}))
+		})
+
+		It("returns the rank of an item", func() {
+			Expect(set.Rank(10)).To(Equal(0))
+			Expect(set.Rank(50)).To(Equal(3))
+			Expect(set.Rank(55)).To(Equal(4))
+			Expect(set.Rank(100)).To(Equal(7))
+		})
+
+		It("selects items by index", func() {
+			Expect(set.Select(0)).To(Equal(10))
+			Expect(set.Select(3)).To(Equal(50))
+			Expect(set.Select(6)).To(Equal(90))
+			Expect(func() { set.Select(7) }).To(Panic())
+		})
+
+		It("is possible to remove items", func() {
+			Expect(set.Remove(50)).To(BeTrue())
+			Expect(set.Remove(50)).To(BeFalse())
+			Expect(set.Items()).To(Equal([]int{10, 20, 30, 70, 80, 90}))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				set.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(set.IsEmpty()).To(BeTrue())
+				Expect(set.Items()).To(BeEmpty())
+			})
+		})
+	})
+
+	It("supports a custom ordering", func() {
+		set := ds.NewSortedSetFunc(func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+		set.Add("banana")
+		set.Add("Apple")
+		set.Add("cherry")
+		Expect(set.Add("APPLE")).To(BeFalse())
+		Expect(set.Items()).To(Equal([]string{"Apple", "banana", "cherry"}))
+	})
+
+	It("remains consistent after many random operations", func() {
+		rng := rand.New(rand.NewPCG(1, 2))
+		expected := ds.NewSet[int](0)
+		for range 5000 {
+			item := rng.IntN(500)
+			if rng.IntN(3) == 0 {
+				Expect(set.Remove(item)).To(Equal(expected.Remove(item)))
+			} else {
+				Expect(set.Add(item)).To(Equal(expected.Add(item)))
+			}
+		}
+		expectedItems := expected.Items()
+		slices.Sort(expectedItems)
+		Expect(set.Items()).To(Equal(expectedItems))
+		for i, item := range expectedItems {
+			Expect(set.Select(i)).To(Equal(item))
+			Expect(set.Rank(item)).To(Equal(i))
+		}
+	})
+
+	Describe("SortedSetFromSlice", func() {
+		It("creates a sorted set from the slice items", func() {
+			set := ds.SortedSetFromSlice([]int{3, 1, 2, 3})
+			Expect(set.Items()).To(Equal([]int{1, 2, 3}))
+		})
+	})
+
+	Describe("set operations", func() {
+		var first, second *ds.SortedSet[int]
+
+		BeforeEach(func() {
+			first = ds.SortedSetFromSlice([]int{5, 1, 3, 7})
+			second = ds.SortedSetFromSlice([]int{6, 3, 4, 5})
+		})
+
+		It("produces a sorted union", func() {
+			result := ds.SortedSetUnion(first, second)
+			Expect(result.Items()).To(Equal([]int{1, 3, 4, 5, 6, 7}))
+		})
+
+		It("produces a sorted difference", func() {
+			result := ds.SortedSetDifference(first, second)
+			Expect(result.Items()).To(Equal([]int{1, 7}))
+		})
+
+		It("produces a sorted intersection", func() {
+			result := ds.SortedSetIntersection(first, second)
+			Expect(result.Items()).To(Equal([]int{3, 5}))
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON in sorted order", func() {
+			set := ds.SortedSetFromSlice([]int{5, 1, 3})
+			data, err := json.Marshal(set)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[1,3,5]`))
+
+			restored := ds.NewSortedSet[int]()
+			restored.Add(7)
+			Expect(json.Unmarshal(data, restored)).To(Succeed())
+			Expect(restored.Items()).To(Equal([]int{1, 3, 5}))
+		})
+
+		It("orders unmarshaled items with its compare function", func() {
+			restored := ds.NewSortedSetFunc(func(a, b int) int {
+				return b - a
+			})
+			Expect(json.Unmarshal([]byte(`[2,3,1,3]`), restored)).To(Succeed())
+			Expect(restored.Items()).To(Equal([]int{3, 2, 1}))
+			restored.Add(4)
+			Expect(restored.Items()).To(Equal([]int{4, 3, 2, 1}))
+		})
+
+		It("marshals a zero value to an empty array", func() {
+			var zero ds.SortedSet[int]
+			data, err := json.Marshal(&zero)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[]`))
+		})
+
+		It("fails to unmarshal into a zero value", func() {
+			var zero ds.SortedSet[int]
+			Expect(json.Unmarshal([]byte(`[1]`), &zero)).ToNot(Succeed())
+			data, err := ds.SortedSetFromSlice([]int{1}).MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(zero.UnmarshalBinary(data)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			restored := ds.NewSortedSet[string]()
+			gobRoundTrip(ds.SortedSetFromSlice([]string{"b", "c", "a"}), restored)
+			Expect(restored.Items()).To(Equal([]string{"a", "b", "c"}))
+		})
+	})
+})
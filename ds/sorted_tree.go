@@ -0,0 +1,436 @@
+package ds
+
+import "slices"
+
+// sortedTree is a left-leaning red-black tree that is augmented with subtree
+// sizes, which allows for order statistics (rank and select) in O(log n).
+// It is the shared implementation of SortedSet and SortedMap.
+type sortedTree[K, V any] struct {
+	compare func(a, b K) int
+	root    *sortedNode[K, V]
+}
+
+type sortedNode[K, V any] struct {
+	key   K
+	value V
+	left  *sortedNode[K, V]
+	right *sortedNode[K, V]
+	red   bool
+	size  int
+}
+
+func (t *sortedTree[K, V]) size() int {
+	return nodeSize(t.root)
+}
+
+func (t *sortedTree[K, V]) find(key K) *sortedNode[K, V] {
+	node := t.root
+	for node != nil {
+		switch c := t.compare(key, node.key); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// put inserts or replaces the value for the specified key and returns true
+// if a new node was added.
+func (t *sortedTree[K, V]) put(key K, value V) bool {
+	var added bool
+	t.root = t.insert(t.root, key, value, &added)
+	t.root.red = false
+	return added
+}
+
+// delete removes the node with the specified key and returns true if such
+// a node existed.
+func (t *sortedTree[K, V]) delete(key K) bool {
+	if t.find(key) == nil {
+		return false
+	}
+	if !isRed(t.root.left) && !isRed(t.root.right) {
+		t.root.red = true
+	}
+	t.root = t.remove(t.root, key)
+	if t.root != nil {
+		t.root.red = false
+	}
+	return true
+}
+
+// build replaces the contents of this tree with the specified keys, which
+// need to be sorted and unique, in O(n) time. The values are matched to the
+// keys by index. If values is nil, all values are zero.
+func (t *sortedTree[K, V]) build(keys []K, values []V) {
+	// Find the largest black height for which the keys suffice to fill all
+	// levels with 2-nodes. The remaining keys are absorbed by 3-nodes.
+	blackHeight := 0
+	for 1<<(blackHeight+1)-1 <= len(keys) {
+		blackHeight++
+	}
+	t.root = buildSortedNodes(keys, values, 0, len(keys), blackHeight)
+	if t.root != nil {
+		t.root.red = false
+	}
+}
+
+// load replaces the contents of this tree with the specified keys, which
+// can be in any order, in O(n log n) time. The values are matched to the
+// keys by index. If a key is repeated, its last value is kept. If values is
+// nil, all values are zero.
+func (t *sortedTree[K, V]) load(keys []K, values []V) {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return t.compare(keys[a], keys[b])
+	})
+	sortedKeys := make([]K, 0, len(keys))
+	var sortedValues []V
+	if values != nil {
+		sortedValues = make([]V, 0, len(values))
+	}
+	for _, index := range order {
+		last := len(sortedKeys) - 1
+		if last < 0 || t.compare(sortedKeys[last], keys[index]) != 0 {
+			sortedKeys = append(sortedKeys, keys[index])
+			if values != nil {
+				sortedValues = append(sortedValues, values[index])
+			}
+		} else if values != nil {
+			sortedValues[last] = values[index]
+		}
+	}
+	t.build(sortedKeys, sortedValues)
+}
+
+func (t *sortedTree[K, V]) clear() {
+	t.root = nil
+}
+
+func (t *sortedTree[K, V]) min() *sortedNode[K, V] {
+	if t.root == nil {
+		return nil
+	}
+	return leftmost(t.root)
+}
+
+func (t *sortedTree[K, V]) max() *sortedNode[K, V] {
+	node := t.root
+	if node == nil {
+		return nil
+	}
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+// floor returns the node with the greatest key that is less than or equal to
+// the specified key.
+func (t *sortedTree[K, V]) floor(key K) *sortedNode[K, V] {
+	var result *sortedNode[K, V]
+	node := t.root
+	for node != nil {
+		switch c := t.compare(key, node.key); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			result = node
+			node = node.right
+		default:
+			return node
+		}
+	}
+	return result
+}
+
+// ceiling returns the node with the least key that is greater than or equal
+// to the specified key.
+func (t *sortedTree[K, V]) ceiling(key K) *sortedNode[K, V] {
+	var result *sortedNode[K, V]
+	node := t.root
+	for node != nil {
+		switch c := t.compare(key, node.key); {
+		case c < 0:
+			result = node
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return node
+		}
+	}
+	return result
+}
+
+// rank returns the number of keys that are strictly less than the specified
+// key.
+func (t *sortedTree[K, V]) rank(key K) int {
+	var result int
+	node := t.root
+	for node != nil {
+		switch c := t.compare(key, node.key); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			result += 1 + nodeSize(node.left)
+			node = node.right
+		default:
+			return result + nodeSize(node.left)
+		}
+	}
+	return result
+}
+
+// selectAt returns the node at the specified zero-based position in sorted
+// order. It panics if the index is out of range.
+func (t *sortedTree[K, V]) selectAt(index int) *sortedNode[K, V] {
+	if index < 0 || index >= t.size() {
+		panic("ds: sorted index out of range")
+	}
+	node := t.root
+	for {
+		leftSize := nodeSize(node.left)
+		switch {
+		case index < leftSize:
+			node = node.left
+		case index > leftSize:
+			index -= leftSize + 1
+			node = node.right
+		default:
+			return node
+		}
+	}
+}
+
+// each visits all nodes in sorted order until yield returns false.
+func (t *sortedTree[K, V]) each(yield func(node *sortedNode[K, V]) bool) {
+	t.eachNode(t.root, yield)
+}
+
+// eachInRange visits all nodes with keys in the inclusive range [lo, hi] in
+// sorted order until yield returns false.
+func (t *sortedTree[K, V]) eachInRange(lo, hi K, yield func(node *sortedNode[K, V]) bool) {
+	t.eachNodeInRange(t.root, lo, hi, yield)
+}
+
+func (t *sortedTree[K, V]) eachNode(node *sortedNode[K, V], yield func(node *sortedNode[K, V]) bool) bool {
+	if node == nil {
+		return true
+	}
+	return t.eachNode(node.left, yield) && yield(node) && t.eachNode(node.right, yield)
+}
+
+func (t *sortedTree[K, V]) eachNodeInRange(node *sortedNode[K, V], lo, hi K, yield func(node *sortedNode[K, V]) bool) bool {
+	if node == nil {
+		return true
+	}
+	cmpLo := t.compare(lo, node.key)
+	cmpHi := t.compare(hi, node.key)
+	if cmpLo < 0 && !t.eachNodeInRange(node.left, lo, hi, yield) {
+		return false
+	}
+	if cmpLo <= 0 && cmpHi >= 0 && !yield(node) {
+		return false
+	}
+	if cmpHi > 0 && !t.eachNodeInRange(node.right, lo, hi, yield) {
+		return false
+	}
+	return true
+}
+
+func (t *sortedTree[K, V]) insert(node *sortedNode[K, V], key K, value V, added *bool) *sortedNode[K, V] {
+	if node == nil {
+		*added = true
+		return &sortedNode[K, V]{
+			key:   key,
+			value: value,
+			red:   true,
+			size:  1,
+		}
+	}
+	switch c := t.compare(key, node.key); {
+	case c < 0:
+		node.left = t.insert(node.left, key, value, added)
+	case c > 0:
+		node.right = t.insert(node.right, key, value, added)
+	default:
+		node.value = value
+	}
+	return balance(node)
+}
+
+func (t *sortedTree[K, V]) remove(node *sortedNode[K, V], key K) *sortedNode[K, V] {
+	if t.compare(key, node.key) < 0 {
+		if !isRed(node.left) && !isRed(node.left.left) {
+			node = moveRedLeft(node)
+		}
+		node.left = t.remove(node.left, key)
+	} else {
+		if isRed(node.left) {
+			node = rotateRight(node)
+		}
+		if t.compare(key, node.key) == 0 && node.right == nil {
+			return nil
+		}
+		if !isRed(node.right) && !isRed(node.right.left) {
+			node = moveRedRight(node)
+		}
+		if t.compare(key, node.key) == 0 {
+			successor := leftmost(node.right)
+			node.key = successor.key
+			node.value = successor.value
+			node.right = removeMin(node.right)
+		} else {
+			node.right = t.remove(node.right, key)
+		}
+	}
+	return balance(node)
+}
+
+// buildSortedNodes creates a subtree out of the keys in the range
+// [start, end), where every path from the root to a leaf passes through
+// exactly blackHeight black nodes. This is possible as long as the number
+// of keys is between 2^blackHeight-1 (only 2-nodes) and 3^blackHeight-1
+// (only 3-nodes). A 3-node is represented as a black node with a red left
+// child.
+func buildSortedNodes[K, V any](keys []K, values []V, start, end, blackHeight int) *sortedNode[K, V] {
+	if blackHeight == 0 {
+		return nil
+	}
+	newNode := func(index int, red bool, left, right *sortedNode[K, V]) *sortedNode[K, V] {
+		node := &sortedNode[K, V]{
+			key:   keys[index],
+			left:  left,
+			right: right,
+			red:   red,
+			size:  1 + nodeSize(left) + nodeSize(right),
+		}
+		if values != nil {
+			node.value = values[index]
+		}
+		return node
+	}
+	maxChildKeys := 1
+	for range blackHeight - 1 {
+		maxChildKeys *= 3
+	}
+	maxChildKeys--
+
+	count := end - start
+	if count-1 <= 2*maxChildKeys {
+		// A 2-node with the keys split evenly between its two children.
+		middle := start + (count-1)/2
+		return newNode(middle, false,
+			buildSortedNodes(keys, values, start, middle, blackHeight-1),
+			buildSortedNodes(keys, values, middle+1, end, blackHeight-1),
+		)
+	}
+	// A 3-node with the keys split evenly between its three children.
+	first := start + (count-2)/3
+	second := first + 1 + (end-first-2)/2
+	red := newNode(first, true,
+		buildSortedNodes(keys, values, start, first, blackHeight-1),
+		buildSortedNodes(keys, values, first+1, second, blackHeight-1),
+	)
+	return newNode(second, false, red,
+		buildSortedNodes(keys, values, second+1, end, blackHeight-1),
+	)
+}
+
+func removeMin[K, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	if node.left == nil {
+		return nil
+	}
+	if !isRed(node.left) && !isRed(node.left.left) {
+		node = moveRedLeft(node)
+	}
+	node.left = removeMin(node.left)
+	return balance(node)
+}
+
+func leftmost[K, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+func nodeSize[K, V any](node *sortedNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func isRed[K, V any](node *sortedNode[K, V]) bool {
+	return node != nil && node.red
+}
+
+func rotateLeft[K, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	pivot := node.right
+	node.right = pivot.left
+	pivot.left = node
+	pivot.red = node.red
+	node.red = true
+	pivot.size = node.size
+	node.size = 1 + nodeSize(node.left) + nodeSize(node.right)
+	return pivot
+}
+
+func rotateRight[K, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	pivot := node.left
+	node.left = pivot.right
+	pivot.right = node
+	pivot.red = node.red
+	node.red = true
+	pivot.size = node.size
+	node.size = 1 + nodeSize(node.left) + nodeSize(node.right)
+	return pivot
+}
+
+func flipColors[K, V any](node *sortedNode[K, V]) {
+	node.red = !node.red
+	node.left.red = !node.left.red
+	node.right.red = !node.right.red
+}
+
+func moveRedLeft[K, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	flipColors(node)
+	if isRed(node.right.left) {
+		node.right = rotateRight(node.right)
+		node = rotateLeft(node)
+		flipColors(node)
+	}
+	return node
+}
+
+func moveRedRight[K, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	flipColors(node)
+	if isRed(node.left.left) {
+		node = rotateRight(node)
+		flipColors(node)
+	}
+	return node
+}
+
+func balance[K, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	if isRed(node.right) && !isRed(node.left) {
+		node = rotateLeft(node)
+	}
+	if isRed(node.left) && isRed(node.left.left) {
+		node = rotateRight(node)
+	}
+	if isRed(node.left) && isRed(node.right) {
+		flipColors(node)
+	}
+	node.size = 1 + nodeSize(node.left) + nodeSize(node.right)
+	return node
+}
@@ -0,0 +1,165 @@
+package ds
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sortedTree", func() {
+	var tree *sortedTree[int, string]
+
+	// checkNode verifies the invariants of the subtree rooted at the
+	// specified node and returns its black height.
+	var checkNode func(node *sortedNode[int, string], lo, hi *int) int
+	checkNode = func(node *sortedNode[int, string], lo, hi *int) int {
+		if node == nil {
+			return 0
+		}
+		if lo != nil {
+			Expect(node.key).To(BeNumerically(">", *lo), "keys are not ordered")
+		}
+		if hi != nil {
+			Expect(node.key).To(BeNumerically("<", *hi), "keys are not ordered")
+		}
+		Expect(isRed(node.right)).To(BeFalse(), "red link leans right at %d", node.key)
+		if node.red {
+			Expect(isRed(node.left)).To(BeFalse(), "two red links in a row at %d", node.key)
+		}
+		Expect(node.size).To(Equal(1+nodeSize(node.left)+nodeSize(node.right)), "wrong size at %d", node.key)
+
+		leftHeight := checkNode(node.left, lo, &node.key)
+		rightHeight := checkNode(node.right, &node.key, hi)
+		Expect(leftHeight).To(Equal(rightHeight), "unbalanced black height at %d", node.key)
+		if node.red {
+			return leftHeight
+		}
+		return leftHeight + 1
+	}
+
+	checkInvariants := func() {
+		Expect(isRed(tree.root)).To(BeFalse(), "root is red")
+		checkNode(tree.root, nil, nil)
+	}
+
+	keysOf := func() []int {
+		result := make([]int, 0, tree.size())
+		tree.each(func(node *sortedNode[int, string]) bool {
+			result = append(result, node.key)
+			return true
+		})
+		return result
+	}
+
+	BeforeEach(func() {
+		tree = &sortedTree[int, string]{
+			compare: cmp.Compare[int],
+		}
+	})
+
+	It("keeps its invariants after random insertions and deletions", func() {
+		random := rand.New(rand.NewPCG(1, 2))
+		expected := make(map[int]string)
+		for range 1000 {
+			key := random.IntN(200)
+			if random.IntN(3) == 0 {
+				_, present := expected[key]
+				Expect(tree.delete(key)).To(Equal(present))
+				delete(expected, key)
+			} else {
+				_, present := expected[key]
+				value := fmt.Sprint(key)
+				Expect(tree.put(key, value)).To(Equal(!present))
+				expected[key] = value
+			}
+			checkInvariants()
+			Expect(tree.size()).To(Equal(len(expected)))
+		}
+		Expect(keysOf()).To(Equal(slices.Sorted(maps.Keys(expected))))
+	})
+
+	It("keeps its invariants when emptied", func() {
+		random := rand.New(rand.NewPCG(3, 4))
+		keys := random.Perm(200)
+		for _, key := range keys {
+			tree.put(key, "")
+		}
+		checkInvariants()
+		random.Shuffle(len(keys), func(i, j int) {
+			keys[i], keys[j] = keys[j], keys[i]
+		})
+		for i, key := range keys {
+			Expect(tree.delete(key)).To(BeTrue())
+			checkInvariants()
+			Expect(tree.size()).To(Equal(len(keys) - i - 1))
+		}
+		Expect(tree.root).To(BeNil())
+	})
+
+	It("supports order statistics after random changes", func() {
+		random := rand.New(rand.NewPCG(5, 6))
+		for range 1000 {
+			key := random.IntN(200)
+			if random.IntN(2) == 0 {
+				tree.delete(key)
+			} else {
+				tree.put(key, "")
+			}
+		}
+		for index, key := range keysOf() {
+			Expect(tree.selectAt(index).key).To(Equal(key))
+			Expect(tree.rank(key)).To(Equal(index))
+		}
+	})
+
+	It("builds valid trees from sorted keys of any size", func() {
+		for count := range 100 {
+			keys := make([]int, count)
+			values := make([]string, count)
+			for i := range keys {
+				keys[i] = i * 2
+				values[i] = fmt.Sprint(i * 2)
+			}
+			tree.build(keys, values)
+			checkInvariants()
+			Expect(tree.size()).To(Equal(count))
+			Expect(keysOf()).To(Equal(keys))
+			for _, key := range keys {
+				Expect(tree.find(key).value).To(Equal(fmt.Sprint(key)))
+			}
+
+			tree.put(-1, "")
+			tree.delete(0)
+			checkInvariants()
+		}
+	})
+
+	It("builds trees with zero values when no values are provided", func() {
+		tree.build([]int{1, 2, 3}, nil)
+		checkInvariants()
+		Expect(tree.find(2).value).To(BeEmpty())
+	})
+
+	It("loads valid trees from unsorted keys", func() {
+		random := rand.New(rand.NewPCG(7, 8))
+		keys := make([]int, 100)
+		values := make([]string, len(keys))
+		expected := make(map[int]string)
+		for i := range keys {
+			keys[i] = random.IntN(50)
+			values[i] = fmt.Sprint(i)
+			expected[keys[i]] = values[i]
+		}
+		tree.load(keys, values)
+		checkInvariants()
+		Expect(keysOf()).To(Equal(slices.Sorted(maps.Keys(expected))))
+		for key, value := range expected {
+			Expect(tree.find(key).value).To(Equal(value))
+		}
+	})
+})
@@ -0,0 +1,407 @@
+package ds
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+)
+
+// NewSparseSet creates a new SparseSet instance that can hold integer items
+// in the range [0, universe).
+//
+// This function panics if the universe is negative.
+func NewSparseSet(universe int) *SparseSet {
+	if universe < 0 {
+		panic("ds: sparse set universe must not be negative")
+	}
+	return &SparseSet{
+		sparse: make([]int, universe),
+	}
+}
+
+// SparseSet is a set of integer items in the range [0, universe). It
+// provides O(1) Add, Remove, Contains and Clear operations and keeps its
+// items in a dense array, which makes iteration cache-friendly.
+//
+// Memory usage is proportional to the universe size, so this structure is
+// best suited for items that are small integers, such as entity IDs.
+type SparseSet struct {
+	sparse []int
+	dense  []int
+}
+
+// Universe returns the exclusive upper bound of items that can be stored
+// in this SparseSet.
+func (s *SparseSet) Universe() int {
+	return len(s.sparse)
+}
+
+// IsEmpty returns whether this SparseSet is empty.
+func (s *SparseSet) IsEmpty() bool {
+	return len(s.dense) == 0
+}
+
+// Size returns the number of items contained in this SparseSet.
+func (s *SparseSet) Size() int {
+	return len(s.dense)
+}
+
+// Add adds the specified item to this SparseSet if it was not present
+// already. This method returns true if the operation was performed and false
+// if the item was already present.
+//
+// This method panics if the item is outside the universe of this SparseSet.
+func (s *SparseSet) Add(item int) bool {
+	if item < 0 || item >= len(s.sparse) {
+		panic("ds: sparse set item out of range")
+	}
+	if s.Contains(item) {
+		return false
+	}
+	s.sparse[item] = len(s.dense)
+	s.dense = append(s.dense, item)
+	return true
+}
+
+// Remove removes the specified item from this SparseSet.
+// This method returns true if there was in fact such an item to be removed
+// and false otherwise.
+//
+// The last item in the dense array takes the place of the removed one, so
+// iteration order is not preserved.
+func (s *SparseSet) Remove(item int) bool {
+	if !s.Contains(item) {
+		return false
+	}
+	index := s.sparse[item]
+	last := s.dense[len(s.dense)-1]
+	s.dense[index] = last
+	s.sparse[last] = index
+	s.dense = s.dense[:len(s.dense)-1]
+	return true
+}
+
+// Contains returns whether this SparseSet holds the specified item. Items
+// outside the universe are never contained.
+func (s *SparseSet) Contains(item int) bool {
+	if item < 0 || item >= len(s.sparse) {
+		return false
+	}
+	index := s.sparse[item]
+	return index < len(s.dense) && s.dense[index] == item
+}
+
+// All returns a sequence over the items of this SparseSet in dense order.
+//
+// The SparseSet should not be modified during iteration.
+func (s *SparseSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, item := range s.dense {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Unbox provides direct access to the dense array of items. The returned
+// slice should not be modified, otherwise the SparseSet might not work
+// correctly afterwards.
+//
+// This method should only be used when performance is critical and memory
+// allocation is not desired.
+func (s *SparseSet) Unbox() []int {
+	return s.dense
+}
+
+// Items returns a slice containing all of the items from this SparseSet in
+// dense order.
+func (s *SparseSet) Items() []int {
+	return append([]int(nil), s.dense...)
+}
+
+// Clear removes all items from this SparseSet in O(1) time.
+func (s *SparseSet) Clear() {
+	s.dense = s.dense[:0]
+}
+
+// MarshalJSON encodes this SparseSet as a JSON object that holds the
+// universe and the items in dense order.
+func (s *SparseSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.encoded())
+}
+
+// UnmarshalJSON decodes a JSON object that was produced by MarshalJSON into
+// this SparseSet. Existing items are removed beforehand.
+func (s *SparseSet) UnmarshalJSON(data []byte) error {
+	var encoded sparseSetEncoding
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return s.replace(encoded)
+}
+
+// MarshalBinary encodes this SparseSet using encoding/gob.
+func (s *SparseSet) MarshalBinary() ([]byte, error) {
+	return marshalGob(s.encoded())
+}
+
+// UnmarshalBinary decodes a SparseSet that was encoded with MarshalBinary.
+// Existing items are removed beforehand.
+func (s *SparseSet) UnmarshalBinary(data []byte) error {
+	var encoded sparseSetEncoding
+	if err := unmarshalGob(data, &encoded); err != nil {
+		return err
+	}
+	return s.replace(encoded)
+}
+
+func (s *SparseSet) encoded() sparseSetEncoding {
+	return sparseSetEncoding{
+		Universe: len(s.sparse),
+		Items:    append(make([]int, 0, len(s.dense)), s.dense...),
+	}
+}
+
+func (s *SparseSet) replace(encoded sparseSetEncoding) error {
+	if encoded.Universe < 0 {
+		return errors.New("sparse set universe must not be negative")
+	}
+	s.sparse = make([]int, encoded.Universe)
+	s.dense = make([]int, 0, len(encoded.Items))
+	for _, item := range encoded.Items {
+		if item < 0 || item >= encoded.Universe {
+			return errors.New("sparse set item out of range")
+		}
+		s.Add(item)
+	}
+	return nil
+}
+
+type sparseSetEncoding struct {
+	Universe int   `json:"universe"`
+	Items    []int `json:"items"`
+}
+
+// NewSparseMap creates a new SparseMap instance that can hold values for
+// integer keys in the range [0, universe).
+//
+// This function panics if the universe is negative.
+func NewSparseMap[V any](universe int) *SparseMap[V] {
+	if universe < 0 {
+		panic("ds: sparse map universe must not be negative")
+	}
+	return &SparseMap[V]{
+		sparse: make([]int, universe),
+	}
+}
+
+// SparseMap is a map from integer keys in the range [0, universe) to
+// values. It works like SparseSet, with values stored in a dense array
+// alongside the keys, which makes it suitable for component storage in
+// entity-component systems.
+type SparseMap[V any] struct {
+	sparse []int
+	keys   []int
+	values []V
+}
+
+// Universe returns the exclusive upper bound of keys that can be stored
+// in this SparseMap.
+func (m *SparseMap[V]) Universe() int {
+	return len(m.sparse)
+}
+
+// IsEmpty returns whether this SparseMap is empty.
+func (m *SparseMap[V]) IsEmpty() bool {
+	return len(m.keys) == 0
+}
+
+// Size returns the number of entries in this SparseMap.
+func (m *SparseMap[V]) Size() int {
+	return len(m.keys)
+}
+
+// Set assigns the specified value to the specified key.
+//
+// This method panics if the key is outside the universe of this SparseMap.
+func (m *SparseMap[V]) Set(key int, value V) {
+	if key < 0 || key >= len(m.sparse) {
+		panic("ds: sparse map key out of range")
+	}
+	if m.Has(key) {
+		m.values[m.sparse[key]] = value
+		return
+	}
+	m.sparse[key] = len(m.keys)
+	m.keys = append(m.keys, key)
+	m.values = append(m.values, value)
+}
+
+// Get returns the value assigned to the specified key. The second return
+// value indicates whether the key was present.
+func (m *SparseMap[V]) Get(key int) (V, bool) {
+	if !m.Has(key) {
+		var zero V
+		return zero, false
+	}
+	return m.values[m.sparse[key]], true
+}
+
+// GetPtr returns a pointer to the value assigned to the specified key, which
+// allows the value to be modified in place. The second return value
+// indicates whether the key was present.
+//
+// The returned pointer is only valid until the next Set or Delete.
+func (m *SparseMap[V]) GetPtr(key int) (*V, bool) {
+	if !m.Has(key) {
+		return nil, false
+	}
+	return &m.values[m.sparse[key]], true
+}
+
+// Has returns whether the specified key is present in this SparseMap. Keys
+// outside the universe are never present.
+func (m *SparseMap[V]) Has(key int) bool {
+	if key < 0 || key >= len(m.sparse) {
+		return false
+	}
+	index := m.sparse[key]
+	return index < len(m.keys) && m.keys[index] == key
+}
+
+// Delete removes the specified key from this SparseMap. This method returns
+// true if there was in fact such a key to be removed and false otherwise.
+//
+// The last entry in the dense arrays takes the place of the removed one, so
+// iteration order is not preserved.
+func (m *SparseMap[V]) Delete(key int) bool {
+	if !m.Has(key) {
+		return false
+	}
+	index := m.sparse[key]
+	lastIndex := len(m.keys) - 1
+	lastKey := m.keys[lastIndex]
+	m.keys[index] = lastKey
+	m.values[index] = m.values[lastIndex]
+	m.sparse[lastKey] = index
+
+	var zero V
+	m.values[lastIndex] = zero
+	m.keys = m.keys[:lastIndex]
+	m.values = m.values[:lastIndex]
+	return true
+}
+
+// Keys returns a sequence over the keys of this SparseMap in dense order.
+//
+// The SparseMap should not be modified during iteration.
+func (m *SparseMap[V]) Keys() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, key := range m.keys {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence over the values of this SparseMap in dense
+// order.
+//
+// The SparseMap should not be modified during iteration.
+func (m *SparseMap[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence over the entries of this SparseMap in dense order.
+//
+// The SparseMap should not be modified during iteration.
+func (m *SparseMap[V]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		for i, key := range m.keys {
+			if !yield(key, m.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all entries from this SparseMap.
+func (m *SparseMap[V]) Clear() {
+	clear(m.values)
+	m.keys = m.keys[:0]
+	m.values = m.values[:0]
+}
+
+// MarshalJSON encodes this SparseMap as a JSON object that holds the
+// universe and the entries in dense order.
+func (m *SparseMap[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.encoded())
+}
+
+// UnmarshalJSON decodes a JSON object that was produced by MarshalJSON into
+// this SparseMap. Existing entries are removed beforehand.
+func (m *SparseMap[V]) UnmarshalJSON(data []byte) error {
+	var encoded sparseMapEncoding[V]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return m.replace(encoded)
+}
+
+// MarshalBinary encodes this SparseMap using encoding/gob.
+func (m *SparseMap[V]) MarshalBinary() ([]byte, error) {
+	return marshalGob(m.encoded())
+}
+
+// UnmarshalBinary decodes a SparseMap that was encoded with MarshalBinary.
+// Existing entries are removed beforehand.
+func (m *SparseMap[V]) UnmarshalBinary(data []byte) error {
+	var encoded sparseMapEncoding[V]
+	if err := unmarshalGob(data, &encoded); err != nil {
+		return err
+	}
+	return m.replace(encoded)
+}
+
+func (m *SparseMap[V]) encoded() sparseMapEncoding[V] {
+	entries := make([]keyValue[int, V], len(m.keys))
+	for i, key := range m.keys {
+		entries[i] = keyValue[int, V]{
+			Key:   key,
+			Value: m.values[i],
+		}
+	}
+	return sparseMapEncoding[V]{
+		Universe: len(m.sparse),
+		Entries:  entries,
+	}
+}
+
+func (m *SparseMap[V]) replace(encoded sparseMapEncoding[V]) error {
+	if encoded.Universe < 0 {
+		return errors.New("sparse map universe must not be negative")
+	}
+	m.sparse = make([]int, encoded.Universe)
+	m.keys = make([]int, 0, len(encoded.Entries))
+	m.values = make([]V, 0, len(encoded.Entries))
+	for _, entry := range encoded.Entries {
+		if entry.Key < 0 || entry.Key >= encoded.Universe {
+			return errors.New("sparse map key out of range")
+		}
+		m.Set(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+type sparseMapEncoding[V any] struct {
+	Universe int                `json:"universe"`
+	Entries  []keyValue[int, V] `json:"entries"`
+}
@@ -0,0 +1,243 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("SparseSet", func() {
+	var set *ds.SparseSet
+
+	BeforeEach(func() {
+		set = ds.NewSparseSet(100)
+	})
+
+	It("is empty by default", func() {
+		Expect(set.IsEmpty()).To(BeTrue())
+		Expect(set.Size()).To(BeZero())
+		Expect(set.Universe()).To(Equal(100))
+	})
+
+	It("panics when adding items outside the universe", func() {
+		Expect(func() { set.Add(-1) }).To(Panic())
+		Expect(func() { set.Add(100) }).To(Panic())
+	})
+
+	It("does not contain items outside the universe", func() {
+		Expect(set.Contains(-1)).To(BeFalse())
+		Expect(set.Contains(100)).To(BeFalse())
+	})
+
+	When("items are added", func() {
+		BeforeEach(func() {
+			Expect(set.Add(7)).To(BeTrue())
+			Expect(set.Add(42)).To(BeTrue())
+			Expect(set.Add(3)).To(BeTrue())
+		})
+
+		It("has the correct size", func() {
+			Expect(set.IsEmpty()).To(BeFalse())
+			Expect(set.Size()).To(Equal(3))
+		})
+
+		It("does not add duplicates", func() {
+			Expect(set.Add(42)).To(BeFalse())
+			Expect(set.Size()).To(Equal(3))
+		})
+
+		It("contains the items", func() {
+			Expect(set.Contains(7)).To(BeTrue())
+			Expect(set.Contains(42)).To(BeTrue())
+			Expect(set.Contains(3)).To(BeTrue())
+			Expect(set.Contains(8)).To(BeFalse())
+		})
+
+		It("iterates items in dense order", func() {
+			Expect(slices.Collect(set.All())).To(Equal([]int{7, 42, 3}))
+			Expect(set.Items()).To(Equal([]int{7, 42, 3}))
+			Expect(set.Unbox()).To(Equal([]int{7, 42, 3}))
+		})
+
+		It("is possible to remove items", func() {
+			Expect(set.Remove(7)).To(BeTrue())
+			Expect(set.Remove(7)).To(BeFalse())
+			Expect(set.Contains(7)).To(BeFalse())
+			Expect(set.Contains(3)).To(BeTrue())
+			Expect(set.Items()).To(Equal([]int{3, 42}))
+		})
+
+		It("is possible to remove the last item", func() {
+			Expect(set.Remove(3)).To(BeTrue())
+			Expect(set.Items()).To(Equal([]int{7, 42}))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				set.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(set.IsEmpty()).To(BeTrue())
+				Expect(set.Contains(7)).To(BeFalse())
+				Expect(set.Contains(42)).To(BeFalse())
+			})
+
+			It("is possible to add items again", func() {
+				Expect(set.Add(42)).To(BeTrue())
+				Expect(set.Contains(42)).To(BeTrue())
+				Expect(set.Contains(7)).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON preserving dense order", func() {
+			set := ds.NewSparseSet(10)
+			set.Add(7)
+			set.Add(2)
+			data, err := json.Marshal(set)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"universe":10,"items":[7,2]}`))
+			var restored ds.SparseSet
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Universe()).To(Equal(10))
+			Expect(restored.Items()).To(Equal([]int{7, 2}))
+		})
+
+		It("fails to unmarshal items outside the universe", func() {
+			var set ds.SparseSet
+			Expect(json.Unmarshal([]byte(`{"universe":2,"items":[2]}`), &set)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			source := ds.NewSparseSet(4)
+			source.Add(3)
+			var restored ds.SparseSet
+			gobRoundTrip(source, &restored)
+			Expect(restored.Contains(3)).To(BeTrue())
+			Expect(restored.Universe()).To(Equal(4))
+		})
+	})
+})
+
+var _ = Describe("SparseMap", func() {
+	var sparseMap *ds.SparseMap[string]
+
+	BeforeEach(func() {
+		sparseMap = ds.NewSparseMap[string](100)
+	})
+
+	It("is empty by default", func() {
+		Expect(sparseMap.IsEmpty()).To(BeTrue())
+		Expect(sparseMap.Size()).To(BeZero())
+		Expect(sparseMap.Universe()).To(Equal(100))
+	})
+
+	It("panics when setting keys outside the universe", func() {
+		Expect(func() { sparseMap.Set(-1, "a") }).To(Panic())
+		Expect(func() { sparseMap.Set(100, "a") }).To(Panic())
+	})
+
+	When("entries are set", func() {
+		BeforeEach(func() {
+			sparseMap.Set(5, "five")
+			sparseMap.Set(10, "ten")
+			sparseMap.Set(1, "one")
+		})
+
+		It("has the correct size", func() {
+			Expect(sparseMap.IsEmpty()).To(BeFalse())
+			Expect(sparseMap.Size()).To(Equal(3))
+		})
+
+		It("is possible to get values", func() {
+			value, ok := sparseMap.Get(10)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("ten"))
+			_, ok = sparseMap.Get(11)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("is possible to modify values in place", func() {
+			value, ok := sparseMap.GetPtr(5)
+			Expect(ok).To(BeTrue())
+			*value = "FIVE"
+			stored, _ := sparseMap.Get(5)
+			Expect(stored).To(Equal("FIVE"))
+		})
+
+		It("replaces existing values", func() {
+			sparseMap.Set(5, "V")
+			var value string
+			Expect(sparseMap.Size()).To(Equal(3))
+			value, _ = sparseMap.Get(5)
+			Expect(value).To(Equal("V"))
+		})
+
+		It("iterates entries in dense order", func() {
+			Expect(slices.Collect(sparseMap.Keys())).To(Equal([]int{5, 10, 1}))
+			Expect(slices.Collect(sparseMap.Values())).To(Equal([]string{"five", "ten", "one"}))
+			Expect(maps.Collect(sparseMap.All())).To(Equal(map[int]string{
+				5: "five", 10: "ten", 1: "one",
+			}))
+		})
+
+		It("is possible to delete entries", func() {
+			var value string
+			Expect(sparseMap.Delete(5)).To(BeTrue())
+			Expect(sparseMap.Delete(5)).To(BeFalse())
+			Expect(sparseMap.Has(5)).To(BeFalse())
+			value, _ = sparseMap.Get(1)
+			Expect(value).To(Equal("one"))
+			Expect(slices.Collect(sparseMap.Keys())).To(Equal([]int{1, 10}))
+			Expect(slices.Collect(sparseMap.Values())).To(Equal([]string{"one", "ten"}))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				sparseMap.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(sparseMap.IsEmpty()).To(BeTrue())
+				Expect(sparseMap.Has(5)).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON preserving dense order", func() {
+			sparseMap := ds.NewSparseMap[string](5)
+			sparseMap.Set(4, "d")
+			sparseMap.Set(0, "a")
+			data, err := json.Marshal(sparseMap)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"universe":5,"entries":[{"key":4,"value":"d"},{"key":0,"value":"a"}]}`))
+			var restored ds.SparseMap[string]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(slices.Collect(restored.Keys())).To(Equal([]int{4, 0}))
+			Expect(slices.Collect(restored.Values())).To(Equal([]string{"d", "a"}))
+		})
+
+		It("fails to unmarshal keys outside the universe", func() {
+			var sparseMap ds.SparseMap[string]
+			Expect(json.Unmarshal([]byte(`{"universe":1,"entries":[{"key":-1,"value":"x"}]}`), &sparseMap)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			source := ds.NewSparseMap[int](3)
+			source.Set(1, 10)
+			var restored ds.SparseMap[int]
+			gobRoundTrip(source, &restored)
+			value, ok := restored.Get(1)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(10))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package ds
 
-import "slices"
+import (
+	"iter"
+	"slices"
+)
 
 // NewStack creates a new Stack instance with the specified initial capacity,
 // which only serves to preallocate memory. Exceeding the initial capacity is
@@ -11,6 +14,14 @@ func NewStack[T any](initCapacity int) *Stack[T] {
 	}
 }
 
+// StackFromSeq creates a new Stack instance and pushes the items of the
+// specified sequence in order, so that the last item ends up at the top.
+func StackFromSeq[T any](src iter.Seq[T]) *Stack[T] {
+	return &Stack[T]{
+		items: slices.Collect(src),
+	}
+}
+
 // Stack is an implementation of a stack data structure. The last inserted
 // item is the first one to be removed (LIFO - last in, first out).
 type Stack[T any] struct {
@@ -48,6 +59,13 @@ func (s *Stack[T]) Peek() T {
 	return s.items[len(s.items)-1]
 }
 
+// All returns a sequence over the items in this Stack, starting from the
+// bottom and ending with the top one. This is the order in which the items
+// were pushed, which means that StackFromSeq(s.All()) recreates the Stack.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return slices.Values(s.items)
+}
+
 // Clear removes all items from this Stack.
 func (s *Stack[T]) Clear() {
 	s.items = s.items[:0]
@@ -57,3 +75,37 @@ func (s *Stack[T]) Clear() {
 func (s *Stack[T]) Clip() {
 	s.items = slices.Clip(s.items)
 }
+
+// MarshalJSON encodes this Stack as a JSON array, starting from the bottom
+// and ending with the top item. This is the order in which the items need
+// to be pushed to recreate the Stack.
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(s.items)
+}
+
+// UnmarshalJSON decodes a JSON array into this Stack, replacing any existing
+// items. The last item in the array ends up at the top.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	items, err := unmarshalItemsJSON[T](data)
+	if err != nil {
+		return err
+	}
+	s.items = items
+	return nil
+}
+
+// MarshalBinary encodes this Stack using encoding/gob.
+func (s *Stack[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(s.items)
+}
+
+// UnmarshalBinary decodes a Stack that was encoded with MarshalBinary,
+// replacing any existing items.
+func (s *Stack[T]) UnmarshalBinary(data []byte) error {
+	items, err := unmarshalItemsBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.items = items
+	return nil
+}
@@ -1,6 +1,9 @@
 package ds_test
 
 import (
+	"encoding/json"
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -24,6 +27,12 @@ var _ = Describe("Stack", func() {
 		Expect(stack.Size()).To(BeZero())
 	})
 
+	It("is possible to construct from a sequence", func() {
+		stack = ds.StackFromSeq(slices.Values([]string{"a", "b", "c"}))
+		Expect(stack.Size()).To(Equal(3))
+		Expect(stack.Pop()).To(Equal("c"))
+	})
+
 	When("items are added", func() {
 		BeforeEach(func() {
 			stack.Push("first")
@@ -45,6 +54,18 @@ var _ = Describe("Stack", func() {
 			Expect(stack.Pop()).To(Equal("first"))
 		})
 
+		It("is possible to iterate the items from the bottom", func() {
+			Expect(slices.Collect(stack.All())).To(Equal([]string{"first", "second", "third"}))
+			Expect(stack.Size()).To(Equal(3))
+		})
+
+		It("is possible to recreate the stack from its sequence", func() {
+			copied := ds.StackFromSeq(stack.All())
+			Expect(copied.Pop()).To(Equal("third"))
+			Expect(copied.Pop()).To(Equal("second"))
+			Expect(copied.Pop()).To(Equal("first"))
+		})
+
 		It("is possible to peek the top item", func() {
 			Expect(stack.Peek()).To(Equal("third"))
 			Expect(stack.Size()).To(Equal(3))
@@ -91,4 +112,29 @@ var _ = Describe("Stack", func() {
 			})
 		})
 	})
+
+	Describe("marshaling", func() {
+		It("round-trips through JSON", func() {
+			stack := ds.NewStack[int](0)
+			stack.Push(1)
+			stack.Push(2)
+			data, err := json.Marshal(stack)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[1,2]`))
+			var restored ds.Stack[int]
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored.Pop()).To(Equal(2))
+			Expect(restored.Pop()).To(Equal(1))
+		})
+
+		It("round-trips through gob", func() {
+			stack := ds.NewStack[int](0)
+			stack.Push(1)
+			stack.Push(2)
+			var restored ds.Stack[int]
+			gobRoundTrip(stack, &restored)
+			Expect(restored.Peek()).To(Equal(2))
+			Expect(restored.Size()).To(Equal(2))
+		})
+	})
 })
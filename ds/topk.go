@@ -0,0 +1,168 @@
+package ds
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/mokiat/gog/internal/binheap"
+)
+
+const topKInitialCapacity = 64
+
+// NewTopK creates a new TopK instance that keeps at most k items, according
+// to the specified better function. When better returns true, the first
+// argument is considered the better one.
+//
+// Memory is allocated as items are pushed, so k only acts as an upper bound
+// and can be arbitrarily large.
+func NewTopK[T any](k int, better func(a, b T) bool) *TopK[T] {
+	k = max(k, 0)
+	return &TopK[T]{
+		k:      k,
+		better: better,
+		heap: NewHeap(min(k, topKInitialCapacity), func(a, b T) bool {
+			return better(b, a)
+		}),
+	}
+}
+
+// TopK is a bounded collector that keeps track of the best k items that have
+// been pushed to it, without having to store all of them.
+//
+// Internally it uses a Heap with the opposite ordering, so that the worst of
+// the kept items is always at the top and can be replaced in O(log k) time.
+type TopK[T any] struct {
+	k      int
+	better func(a, b T) bool
+	heap   *Heap[T]
+}
+
+// K returns the maximum number of items that this TopK keeps.
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+// Size returns the number of items currently kept by this TopK.
+func (t *TopK[T]) Size() int {
+	return t.heap.Size()
+}
+
+// IsEmpty returns true if no items are kept by this TopK.
+func (t *TopK[T]) IsEmpty() bool {
+	return t.heap.IsEmpty()
+}
+
+// Push offers an item to this TopK. The method returns true if the item is
+// kept and false if it was worse than all of the currently kept items.
+func (t *TopK[T]) Push(value T) bool {
+	if t.heap.Size() < t.k {
+		t.heap.Push(value)
+		return true
+	}
+	if t.k == 0 || !t.better(value, t.heap.Peek()) {
+		return false
+	}
+	t.heap.items[0] = value
+	t.heap.fix(0)
+	return true
+}
+
+// PushSeq offers all items of the specified sequence to this TopK.
+func (t *TopK[T]) PushSeq(src iter.Seq[T]) {
+	for value := range src {
+		t.Push(value)
+	}
+}
+
+// Items returns the kept items as a new slice, sorted from best to worst.
+func (t *TopK[T]) Items() []T {
+	result := slices.Clone(t.heap.items)
+	slices.SortFunc(result, binheap.Compare(t.better))
+	return result
+}
+
+// All returns a sequence over the kept items without sorting them.
+//
+// Note: The items are returned in an unspecified order. Use Items to get
+// them sorted from best to worst.
+func (t *TopK[T]) All() iter.Seq[T] {
+	return t.heap.All()
+}
+
+// Clear removes all kept items from this TopK.
+func (t *TopK[T]) Clear() {
+	t.heap.Clear()
+}
+
+// MarshalJSON encodes this TopK as a JSON object that holds k and the kept
+// items sorted from best to worst.
+func (t *TopK[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.encoded())
+}
+
+// UnmarshalJSON decodes a JSON object that was produced by MarshalJSON into
+// this TopK. Existing items are removed and k is replaced.
+//
+// The items are ordered with the better function of this TopK, which means
+// that it needs to be created through NewTopK. Decoding into a zero value
+// returns an error.
+func (t *TopK[T]) UnmarshalJSON(data []byte) error {
+	var encoded topKEncoding[T]
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return t.replace(encoded)
+}
+
+// MarshalBinary encodes this TopK using encoding/gob.
+func (t *TopK[T]) MarshalBinary() ([]byte, error) {
+	return marshalGob(t.encoded())
+}
+
+// UnmarshalBinary decodes a TopK that was encoded with MarshalBinary.
+// Existing items are removed and k is replaced. As with UnmarshalJSON, this
+// TopK needs to have a better function.
+func (t *TopK[T]) UnmarshalBinary(data []byte) error {
+	var encoded topKEncoding[T]
+	if err := unmarshalGob(data, &encoded); err != nil {
+		return err
+	}
+	return t.replace(encoded)
+}
+
+func (t *TopK[T]) encoded() topKEncoding[T] {
+	var items []T
+	if t.heap != nil {
+		items = t.Items()
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return topKEncoding[T]{
+		K:     t.k,
+		Items: items,
+	}
+}
+
+// replace resets this TopK to the encoded state. As with NewTopK, k cannot
+// be negative.
+func (t *TopK[T]) replace(encoded topKEncoding[T]) error {
+	if t.heap == nil {
+		return errors.New("top k has no better function")
+	}
+	if encoded.K < 0 {
+		return errors.New("top k cannot be negative")
+	}
+	if len(encoded.Items) > encoded.K {
+		return errors.New("top k items exceed k")
+	}
+	t.k = encoded.K
+	return t.heap.replace(encoded.Items)
+}
+
+type topKEncoding[T any] struct {
+	K     int `json:"k"`
+	Items []T `json:"items"`
+}
@@ -0,0 +1,148 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("TopK", func() {
+	var topK *ds.TopK[int]
+
+	BeforeEach(func() {
+		topK = ds.NewTopK(3, func(a, b int) bool {
+			return a > b
+		})
+	})
+
+	It("is empty by default", func() {
+		Expect(topK.IsEmpty()).To(BeTrue())
+		Expect(topK.Size()).To(BeZero())
+		Expect(topK.Items()).To(BeEmpty())
+	})
+
+	It("has the configured k", func() {
+		Expect(topK.K()).To(Equal(3))
+	})
+
+	When("fewer than k items are pushed", func() {
+		BeforeEach(func() {
+			Expect(topK.Push(5)).To(BeTrue())
+			Expect(topK.Push(9)).To(BeTrue())
+		})
+
+		It("keeps all of them", func() {
+			Expect(topK.Size()).To(Equal(2))
+			Expect(topK.Items()).To(Equal([]int{9, 5}))
+		})
+	})
+
+	When("more than k items are pushed", func() {
+		BeforeEach(func() {
+			topK.PushSeq(slices.Values([]int{4, 17, 1, 8, 23, 8, 2, 15}))
+		})
+
+		It("keeps only k items", func() {
+			Expect(topK.Size()).To(Equal(3))
+		})
+
+		It("keeps the best items in sorted order", func() {
+			Expect(topK.Items()).To(Equal([]int{23, 17, 15}))
+		})
+
+		It("is possible to iterate the kept items", func() {
+			Expect(slices.Collect(topK.All())).To(ConsistOf(23, 17, 15))
+		})
+
+		It("rejects worse items", func() {
+			Expect(topK.Push(10)).To(BeFalse())
+			Expect(topK.Items()).To(Equal([]int{23, 17, 15}))
+		})
+
+		It("accepts better items", func() {
+			Expect(topK.Push(20)).To(BeTrue())
+			Expect(topK.Items()).To(Equal([]int{23, 20, 17}))
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				topK.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(topK.IsEmpty()).To(BeTrue())
+			})
+		})
+	})
+
+	It("supports a very large k", func() {
+		topK = ds.NewTopK(math.MaxInt, func(a, b int) bool {
+			return a > b
+		})
+		topK.PushSeq(slices.Values([]int{2, 3, 1}))
+		Expect(topK.Items()).To(Equal([]int{3, 2, 1}))
+	})
+
+	It("keeps nothing when k is zero", func() {
+		topK = ds.NewTopK(0, func(a, b int) bool {
+			return a > b
+		})
+		Expect(topK.Push(1)).To(BeFalse())
+		Expect(topK.IsEmpty()).To(BeTrue())
+	})
+
+	Describe("marshaling", func() {
+		newTopK := func() *ds.TopK[int] {
+			return ds.NewTopK(1, func(a, b int) bool {
+				return a > b
+			})
+		}
+
+		BeforeEach(func() {
+			topK.PushSeq(slices.Values([]int{4, 9, 1, 7}))
+		})
+
+		It("round-trips through JSON from best to worst", func() {
+			data, err := json.Marshal(topK)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"k":3,"items":[9,7,4]}`))
+
+			restored := newTopK()
+			Expect(json.Unmarshal(data, restored)).To(Succeed())
+			Expect(restored.K()).To(Equal(3))
+			Expect(restored.Items()).To(Equal([]int{9, 7, 4}))
+			Expect(restored.Push(5)).To(BeTrue())
+			Expect(restored.Items()).To(Equal([]int{9, 7, 5}))
+		})
+
+		It("fails to unmarshal invalid state", func() {
+			Expect(json.Unmarshal([]byte(`{"k":-1,"items":[]}`), topK)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`{"k":1,"items":[1,2]}`), topK)).ToNot(Succeed())
+			Expect(topK.Items()).To(Equal([]int{9, 7, 4}))
+		})
+
+		It("marshals a zero value with no items", func() {
+			var zero ds.TopK[int]
+			data, err := json.Marshal(&zero)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"k":0,"items":[]}`))
+		})
+
+		It("fails to unmarshal into a zero value", func() {
+			var zero ds.TopK[int]
+			Expect(json.Unmarshal([]byte(`{"k":1,"items":[1]}`), &zero)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			restored := newTopK()
+			gobRoundTrip(topK, restored)
+			Expect(restored.K()).To(Equal(3))
+			Expect(restored.Items()).To(Equal([]int{9, 7, 4}))
+		})
+	})
+})
@@ -0,0 +1,350 @@
+package ds
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// NewTrie creates a new empty Trie instance.
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{
+		root: &trieNode[V]{},
+	}
+}
+
+// Trie represents a prefix tree that maps string keys to values. It allows
+// for efficient lookups of all keys that share a given prefix, as well as
+// of the longest key that is a prefix of a given string.
+//
+// The Trie uses a compressed (radix) representation, where chains of nodes
+// with a single child are merged into one, which keeps memory usage
+// reasonable for large dictionaries. Keys are compared byte-wise, which
+// means that iteration yields keys in lexicographic order.
+type Trie[V any] struct {
+	root *trieNode[V]
+	size int
+}
+
+type trieNode[V any] struct {
+	label    string
+	value    V
+	hasValue bool
+	children []*trieNode[V] // sorted by the first byte of the label
+}
+
+// Size returns the number of keys stored in this Trie.
+func (t *Trie[V]) Size() int {
+	return t.size
+}
+
+// IsEmpty returns true if there are no keys in this Trie.
+func (t *Trie[V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Put stores the specified value for the specified key, replacing any
+// previous value.
+func (t *Trie[V]) Put(key string, value V) {
+	node := t.root
+	for key != "" {
+		index, found := node.childIndex(key[0])
+		if !found {
+			child := &trieNode[V]{
+				label:    key,
+				value:    value,
+				hasValue: true,
+			}
+			node.children = slices.Insert(node.children, index, child)
+			t.size++
+			return
+		}
+		child := node.children[index]
+		common := commonPrefixLength(key, child.label)
+		if common < len(child.label) {
+			split := &trieNode[V]{
+				label:    child.label[:common],
+				children: []*trieNode[V]{child},
+			}
+			child.label = child.label[common:]
+			node.children[index] = split
+			child = split
+		}
+		key = key[common:]
+		node = child
+	}
+	if !node.hasValue {
+		t.size++
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// Get returns the value stored for the specified key. The second return
+// value is false if there is no such key.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	node := t.find(key)
+	if node == nil || !node.hasValue {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Has returns whether this Trie contains the specified key.
+func (t *Trie[V]) Has(key string) bool {
+	node := t.find(key)
+	return node != nil && node.hasValue
+}
+
+// Delete removes the specified key from this Trie. This method returns true
+// if there was such a key and false otherwise.
+func (t *Trie[V]) Delete(key string) bool {
+	var (
+		parent     *trieNode[V]
+		childIndex int
+	)
+	node := t.root
+	for key != "" {
+		index, found := node.childIndex(key[0])
+		if !found || !strings.HasPrefix(key, node.children[index].label) {
+			return false
+		}
+		parent, childIndex = node, index
+		node = node.children[index]
+		key = key[len(node.label):]
+	}
+	if !node.hasValue {
+		return false
+	}
+	var zero V
+	node.value = zero
+	node.hasValue = false
+	t.size--
+
+	if parent == nil {
+		return true // the empty key is stored in the root
+	}
+	switch len(node.children) {
+	case 0:
+		parent.children = slices.Delete(parent.children, childIndex, childIndex+1)
+		if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeWithChild()
+		}
+	case 1:
+		node.mergeWithChild()
+	}
+	return true
+}
+
+// LongestPrefixOf returns the longest key in this Trie that is a prefix of
+// the specified string, together with its value. The last return value is
+// false if no key is a prefix of the string.
+func (t *Trie[V]) LongestPrefixOf(s string) (string, V, bool) {
+	var (
+		bestNode   *trieNode[V]
+		bestLength int
+		consumed   int
+	)
+	node := t.root
+	for {
+		if node.hasValue {
+			bestNode = node
+			bestLength = consumed
+		}
+		if consumed == len(s) {
+			break
+		}
+		index, found := node.childIndex(s[consumed])
+		if !found || !strings.HasPrefix(s[consumed:], node.children[index].label) {
+			break
+		}
+		node = node.children[index]
+		consumed += len(node.label)
+	}
+	if bestNode == nil {
+		var zero V
+		return "", zero, false
+	}
+	return s[:bestLength], bestNode.value, true
+}
+
+// WithPrefix returns a sequence over all keys in this Trie that start with
+// the specified prefix, together with their values. The keys are yielded in
+// lexicographic order.
+func (t *Trie[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		node := t.root
+		var path strings.Builder
+		for remaining := prefix; remaining != ""; {
+			index, found := node.childIndex(remaining[0])
+			if !found {
+				return
+			}
+			child := node.children[index]
+			switch {
+			case strings.HasPrefix(remaining, child.label):
+				remaining = remaining[len(child.label):]
+			case strings.HasPrefix(child.label, remaining):
+				remaining = ""
+			default:
+				return
+			}
+			path.WriteString(child.label)
+			node = child
+		}
+		walkTrie(node, []byte(path.String()), yield)
+	}
+}
+
+// All returns a sequence over all keys in this Trie, together with their
+// values. The keys are yielded in lexicographic order.
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return t.WithPrefix("")
+}
+
+// Walk calls the specified function for each key in this Trie, together
+// with its value, in lexicographic order. Walking stops when the function
+// returns false.
+func (t *Trie[V]) Walk(fn func(key string, value V) bool) {
+	walkTrie(t.root, nil, fn)
+}
+
+// Clear removes all keys from this Trie.
+func (t *Trie[V]) Clear() {
+	t.root = &trieNode[V]{}
+	t.size = 0
+}
+
+// MarshalJSON encodes this Trie as a JSON object, where the keys appear in
+// lexicographic order.
+func (t *Trie[V]) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, entry := range t.entries() {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		keyData, err := json.Marshal(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(keyData)
+		buffer.WriteByte(':')
+		valueData, err := json.Marshal(entry.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling value for key %q: %w", entry.Key, err)
+		}
+		buffer.Write(valueData)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into this Trie. Existing keys are
+// removed beforehand.
+func (t *Trie[V]) UnmarshalJSON(data []byte) error {
+	var items map[string]V
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	t.Clear()
+	for key, value := range items {
+		t.Put(key, value)
+	}
+	return nil
+}
+
+// MarshalBinary encodes this Trie using encoding/gob, with the keys in
+// lexicographic order.
+func (t *Trie[V]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(t.entries())
+}
+
+// UnmarshalBinary decodes a Trie that was encoded with MarshalBinary.
+// Existing keys are removed beforehand.
+func (t *Trie[V]) UnmarshalBinary(data []byte) error {
+	entries, err := unmarshalItemsBinary[keyValue[string, V]](data)
+	if err != nil {
+		return err
+	}
+	t.Clear()
+	for _, entry := range entries {
+		t.Put(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// entries returns all key-value pairs of this Trie in lexicographic order.
+// It is safe to use on a zero value Trie.
+func (t *Trie[V]) entries() []keyValue[string, V] {
+	result := make([]keyValue[string, V], 0, t.size)
+	if t.root == nil {
+		return result
+	}
+	walkTrie(t.root, nil, func(key string, value V) bool {
+		result = append(result, keyValue[string, V]{
+			Key:   key,
+			Value: value,
+		})
+		return true
+	})
+	return result
+}
+
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	node := t.root
+	for key != "" {
+		index, found := node.childIndex(key[0])
+		if !found || !strings.HasPrefix(key, node.children[index].label) {
+			return nil
+		}
+		node = node.children[index]
+		key = key[len(node.label):]
+	}
+	return node
+}
+
+// childIndex returns the index of the child whose label starts with the
+// specified byte, or the index at which such a child should be inserted.
+func (n *trieNode[V]) childIndex(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(child *trieNode[V], b byte) int {
+		return cmp.Compare(child.label[0], b)
+	})
+}
+
+// mergeWithChild merges this node with its only child, which is used to keep
+// the tree compressed after deletions.
+func (n *trieNode[V]) mergeWithChild() {
+	child := n.children[0]
+	n.label += child.label
+	n.value = child.value
+	n.hasValue = child.hasValue
+	n.children = child.children
+}
+
+func walkTrie[V any](node *trieNode[V], key []byte, yield func(string, V) bool) bool {
+	if node.hasValue && !yield(string(key), node.value) {
+		return false
+	}
+	for _, child := range node.children {
+		if !walkTrie(child, append(key, child.label...), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefixLength(a, b string) int {
+	length := min(len(a), len(b))
+	for i := range length {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return length
+}
@@ -0,0 +1,201 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"maps"
+	"math/rand/v2"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("Trie", func() {
+	var trie *ds.Trie[int]
+
+	keysOf := func(src func(yield func(string, int) bool)) []string {
+		var keys []string
+		for key := range src {
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	BeforeEach(func() {
+		trie = ds.NewTrie[int]()
+	})
+
+	It("is empty by default", func() {
+		Expect(trie.IsEmpty()).To(BeTrue())
+		Expect(trie.Size()).To(BeZero())
+		Expect(keysOf(trie.All())).To(BeEmpty())
+	})
+
+	When("keys are added", func() {
+		BeforeEach(func() {
+			trie.Put("team", 1)
+			trie.Put("tea", 2)
+			trie.Put("ten", 3)
+			trie.Put("to", 4)
+			trie.Put("t", 5)
+			trie.Put("inn", 6)
+		})
+
+		It("has the correct size", func() {
+			Expect(trie.IsEmpty()).To(BeFalse())
+			Expect(trie.Size()).To(Equal(6))
+		})
+
+		It("is possible to get the values", func() {
+			value, ok := trie.Get("tea")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(2))
+			Expect(trie.Has("team")).To(BeTrue())
+			Expect(trie.Has("te")).To(BeFalse())
+			Expect(trie.Has("teams")).To(BeFalse())
+			Expect(trie.Has("")).To(BeFalse())
+		})
+
+		It("replaces the values of existing keys", func() {
+			trie.Put("tea", 20)
+			Expect(trie.Size()).To(Equal(6))
+			value, _ := trie.Get("tea")
+			Expect(value).To(Equal(20))
+		})
+
+		It("iterates keys in lexicographic order", func() {
+			Expect(keysOf(trie.All())).To(Equal([]string{"inn", "t", "tea", "team", "ten", "to"}))
+		})
+
+		It("iterates keys with a prefix", func() {
+			Expect(keysOf(trie.WithPrefix("te"))).To(Equal([]string{"tea", "team", "ten"}))
+			Expect(keysOf(trie.WithPrefix("tea"))).To(Equal([]string{"tea", "team"}))
+			Expect(keysOf(trie.WithPrefix("tex"))).To(BeEmpty())
+			Expect(keysOf(trie.WithPrefix("x"))).To(BeEmpty())
+			Expect(maps.Collect(trie.WithPrefix("i"))).To(Equal(map[string]int{"inn": 6}))
+		})
+
+		It("walks all keys until stopped", func() {
+			var keys []string
+			trie.Walk(func(key string, value int) bool {
+				keys = append(keys, key)
+				return len(keys) < 3
+			})
+			Expect(keys).To(Equal([]string{"inn", "t", "tea"}))
+		})
+
+		It("finds the longest prefix of a string", func() {
+			key, value, ok := trie.LongestPrefixOf("teammate")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("team"))
+			Expect(value).To(Equal(1))
+
+			key, _, ok = trie.LongestPrefixOf("tex")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("t"))
+
+			_, _, ok = trie.LongestPrefixOf("xyz")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("is possible to delete keys", func() {
+			Expect(trie.Delete("tea")).To(BeTrue())
+			Expect(trie.Delete("tea")).To(BeFalse())
+			Expect(trie.Delete("te")).To(BeFalse())
+			Expect(trie.Has("tea")).To(BeFalse())
+			Expect(trie.Has("team")).To(BeTrue())
+			Expect(trie.Size()).To(Equal(5))
+			Expect(keysOf(trie.All())).To(Equal([]string{"inn", "t", "team", "ten", "to"}))
+		})
+
+		It("supports the empty key", func() {
+			trie.Put("", 0)
+			Expect(trie.Has("")).To(BeTrue())
+			key, _, ok := trie.LongestPrefixOf("xyz")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(BeEmpty())
+			Expect(trie.Delete("")).To(BeTrue())
+			Expect(trie.Has("")).To(BeFalse())
+		})
+
+		When("cleared", func() {
+			BeforeEach(func() {
+				trie.Clear()
+			})
+
+			It("becomes empty", func() {
+				Expect(trie.IsEmpty()).To(BeTrue())
+				Expect(keysOf(trie.All())).To(BeEmpty())
+			})
+		})
+	})
+
+	It("remains consistent after many random operations", func() {
+		rng := rand.New(rand.NewPCG(3, 4))
+		expected := make(map[string]int)
+		randomKey := func() string {
+			key := make([]byte, rng.IntN(5))
+			for i := range key {
+				key[i] = "abc"[rng.IntN(3)]
+			}
+			return string(key)
+		}
+		for i := range 5000 {
+			key := randomKey()
+			if rng.IntN(3) == 0 {
+				_, ok := expected[key]
+				Expect(trie.Delete(key)).To(Equal(ok))
+				delete(expected, key)
+			} else {
+				trie.Put(key, i)
+				expected[key] = i
+			}
+		}
+		Expect(trie.Size()).To(Equal(len(expected)))
+		Expect(maps.Collect(trie.All())).To(Equal(expected))
+		expectedKeys := slices.Sorted(maps.Keys(expected))
+		Expect(keysOf(trie.All())).To(Equal(expectedKeys))
+	})
+
+	Describe("marshaling", func() {
+		It("marshals to a JSON object in lexicographic order", func() {
+			trie := ds.NewTrie[int]()
+			trie.Put("car", 2)
+			trie.Put("cat", 3)
+			trie.Put("ant", 1)
+			data, err := json.Marshal(trie)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"ant":1,"car":2,"cat":3}`))
+		})
+
+		It("marshals a zero value to an empty object", func() {
+			var trie ds.Trie[int]
+			data, err := json.Marshal(&trie)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`{}`))
+		})
+
+		It("unmarshals into a zero value", func() {
+			var trie ds.Trie[int]
+			Expect(json.Unmarshal([]byte(`{"car":2,"":1}`), &trie)).To(Succeed())
+			Expect(trie.Size()).To(Equal(2))
+			value, ok := trie.Get("car")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(2))
+		})
+
+		It("round-trips through gob", func() {
+			source := ds.NewTrie[string]()
+			source.Put("a", "x")
+			source.Put("ab", "y")
+			var restored ds.Trie[string]
+			gobRoundTrip(source, &restored)
+			value, ok := restored.Get("ab")
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("y"))
+			Expect(restored.Size()).To(Equal(2))
+		})
+	})
+})
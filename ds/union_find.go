@@ -0,0 +1,248 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// NewUnionFind creates a new UnionFind instance with the specified initial
+// capacity, which is only used to preallocate memory and does not act as an
+// upper bound.
+func NewUnionFind[T comparable](initialCapacity int) *UnionFind[T] {
+	return &UnionFind[T]{
+		indices: make(map[T]int, initialCapacity),
+		items:   make([]T, 0, initialCapacity),
+		parents: make([]int, 0, initialCapacity),
+		ranks:   make([]int, 0, initialCapacity),
+		sizes:   make([]int, 0, initialCapacity),
+	}
+}
+
+// UnionFind represents a disjoint set data structure, where items are
+// partitioned into non-overlapping groups.
+//
+// It uses path compression and union by rank, which makes all operations
+// run in nearly constant amortized time.
+type UnionFind[T comparable] struct {
+	indices map[T]int
+	items   []T
+	parents []int
+	ranks   []int
+	sizes   []int
+	groups  int
+}
+
+// IsEmpty returns whether this UnionFind has no items.
+func (u *UnionFind[T]) IsEmpty() bool {
+	return len(u.items) == 0
+}
+
+// Size returns the number of items contained in this UnionFind.
+func (u *UnionFind[T]) Size() int {
+	return len(u.items)
+}
+
+// GroupCount returns the number of disjoint groups in this UnionFind.
+func (u *UnionFind[T]) GroupCount() int {
+	return u.groups
+}
+
+// Add adds the specified item as a new single-item group, if it was not
+// present already. This method returns true if the operation was performed
+// and false if the item was already present.
+func (u *UnionFind[T]) Add(item T) bool {
+	if _, ok := u.indices[item]; ok {
+		return false
+	}
+	u.add(item)
+	return true
+}
+
+// Contains returns whether this UnionFind holds the specified item.
+func (u *UnionFind[T]) Contains(item T) bool {
+	_, ok := u.indices[item]
+	return ok
+}
+
+// Union merges the groups of the two specified items. Items that are not
+// present are added first. This method returns true if the groups were
+// merged and false if the items were already in the same group.
+func (u *UnionFind[T]) Union(a, b T) bool {
+	rootA := u.findRoot(u.indexOf(a))
+	rootB := u.findRoot(u.indexOf(b))
+	if rootA == rootB {
+		return false
+	}
+	switch {
+	case u.ranks[rootA] < u.ranks[rootB]:
+		rootA, rootB = rootB, rootA
+	case u.ranks[rootA] == u.ranks[rootB]:
+		u.ranks[rootA]++
+	}
+	u.parents[rootB] = rootA
+	u.sizes[rootA] += u.sizes[rootB]
+	u.groups--
+	return true
+}
+
+// Find returns the representative item of the group that the specified
+// item belongs to. The second return value is false if the item is not
+// present.
+func (u *UnionFind[T]) Find(item T) (T, bool) {
+	index, ok := u.indices[item]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return u.items[u.findRoot(index)], true
+}
+
+// Connected returns whether the two specified items belong to the same
+// group. Items that are not present are not connected to anything.
+func (u *UnionFind[T]) Connected(a, b T) bool {
+	indexA, okA := u.indices[a]
+	indexB, okB := u.indices[b]
+	if !okA || !okB {
+		return false
+	}
+	return u.findRoot(indexA) == u.findRoot(indexB)
+}
+
+// SetSize returns the number of items in the group that the specified item
+// belongs to. If the item is not present, zero is returned.
+func (u *UnionFind[T]) SetSize(item T) int {
+	index, ok := u.indices[item]
+	if !ok {
+		return 0
+	}
+	return u.sizes[u.findRoot(index)]
+}
+
+// Groups returns a sequence over all groups in this UnionFind. Each group
+// is provided as a new slice of items.
+//
+// Note: The groups, as well as the items within them, are returned in an
+// unspecified order.
+func (u *UnionFind[T]) Groups() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		groups := make(map[int][]T, u.groups)
+		for index, item := range u.items {
+			root := u.findRoot(index)
+			if groups[root] == nil {
+				groups[root] = make([]T, 0, u.sizes[root])
+			}
+			groups[root] = append(groups[root], item)
+		}
+		for _, group := range groups {
+			if !yield(group) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all items from this UnionFind.
+func (u *UnionFind[T]) Clear() {
+	clear(u.indices)
+	clear(u.items)
+	u.items = u.items[:0]
+	u.parents = u.parents[:0]
+	u.ranks = u.ranks[:0]
+	u.sizes = u.sizes[:0]
+	u.groups = 0
+}
+
+// MarshalJSON encodes this UnionFind as a JSON array of groups, where each
+// group is an array of items. If the items are of an integer, float or
+// string type, they are sorted within each group and the groups are sorted
+// by their first item, so that the output is deterministic.
+func (u *UnionFind[T]) MarshalJSON() ([]byte, error) {
+	return marshalItemsJSON(u.encoded())
+}
+
+// UnmarshalJSON decodes a JSON array of groups into this UnionFind.
+// Existing items are removed beforehand.
+func (u *UnionFind[T]) UnmarshalJSON(data []byte) error {
+	groups, err := unmarshalItemsJSON[[]T](data)
+	if err != nil {
+		return err
+	}
+	return u.replace(groups)
+}
+
+// MarshalBinary encodes this UnionFind using encoding/gob, with the same
+// ordering guarantees as MarshalJSON.
+func (u *UnionFind[T]) MarshalBinary() ([]byte, error) {
+	return marshalItemsBinary(u.encoded())
+}
+
+// UnmarshalBinary decodes a UnionFind that was encoded with MarshalBinary.
+// Existing items are removed beforehand.
+func (u *UnionFind[T]) UnmarshalBinary(data []byte) error {
+	groups, err := unmarshalItemsBinary[[]T](data)
+	if err != nil {
+		return err
+	}
+	return u.replace(groups)
+}
+
+func (u *UnionFind[T]) encoded() [][]T {
+	result := make([][]T, 0, u.groups)
+	for group := range u.Groups() {
+		sortIfOrdered(group)
+		result = append(result, group)
+	}
+	sortByOrderedKey(result, func(group []T) T {
+		return group[0]
+	})
+	return result
+}
+
+func (u *UnionFind[T]) replace(groups [][]T) error {
+	if u.indices == nil {
+		u.indices = make(map[T]int)
+	}
+	u.Clear()
+	for _, group := range groups {
+		if len(group) == 0 {
+			return errors.New("union find group must not be empty")
+		}
+		for _, item := range group {
+			if !u.Add(item) {
+				return fmt.Errorf("item %v appears in more than one group", item)
+			}
+			u.Union(group[0], item)
+		}
+	}
+	return nil
+}
+
+func (u *UnionFind[T]) indexOf(item T) int {
+	if index, ok := u.indices[item]; ok {
+		return index
+	}
+	return u.add(item)
+}
+
+func (u *UnionFind[T]) add(item T) int {
+	index := len(u.items)
+	u.indices[item] = index
+	u.items = append(u.items, item)
+	u.parents = append(u.parents, index)
+	u.ranks = append(u.ranks, 0)
+	u.sizes = append(u.sizes, 1)
+	u.groups++
+	return index
+}
+
+func (u *UnionFind[T]) findRoot(index int) int {
+	root := index
+	for u.parents[root] != root {
+		root = u.parents[root]
+	}
+	for u.parents[index] != root {
+		index, u.parents[index] = u.parents[index], root
+	}
+	return root
+}
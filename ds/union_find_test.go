@@ -0,0 +1,147 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/ds"
+)
+
+var _ = Describe("UnionFind", func() {
+	var unionFind *ds.UnionFind[string]
+
+	BeforeEach(func() {
+		unionFind = ds.NewUnionFind[string](0)
+	})
+
+	It("is empty by default", func() {
+		Expect(unionFind.IsEmpty()).To(BeTrue())
+		Expect(unionFind.Size()).To(BeZero())
+		Expect(unionFind.GroupCount()).To(BeZero())
+	})
+
+	It("does not find missing items", func() {
+		_, ok := unionFind.Find("missing")
+		Expect(ok).To(BeFalse())
+		Expect(unionFind.SetSize("missing")).To(BeZero())
+		Expect(unionFind.Connected("missing", "missing")).To(BeFalse())
+	})
+
+	When("items are added", func() {
+		BeforeEach(func() {
+			Expect(unionFind.Add("a")).To(BeTrue())
+			Expect(unionFind.Add("b")).To(BeTrue())
+			Expect(unionFind.Add("c")).To(BeTrue())
+		})
+
+		It("does not add duplicates", func() {
+			Expect(unionFind.Add("a")).To(BeFalse())
+			Expect(unionFind.Size()).To(Equal(3))
+		})
+
+		It("places each item in its own group", func() {
+			Expect(unionFind.GroupCount()).To(Equal(3))
+			Expect(unionFind.Connected("a", "b")).To(BeFalse())
+			Expect(unionFind.SetSize("a")).To(Equal(1))
+			representative, ok := unionFind.Find("a")
+			Expect(ok).To(BeTrue())
+			Expect(representative).To(Equal("a"))
+		})
+
+		When("items are joined", func() {
+			BeforeEach(func() {
+				Expect(unionFind.Union("a", "b")).To(BeTrue())
+				Expect(unionFind.Union("d", "e")).To(BeTrue())
+				Expect(unionFind.Union("e", "c")).To(BeTrue())
+			})
+
+			It("adds missing items", func() {
+				Expect(unionFind.Size()).To(Equal(5))
+				Expect(unionFind.Contains("d")).To(BeTrue())
+			})
+
+			It("reports the groups", func() {
+				Expect(unionFind.GroupCount()).To(Equal(2))
+				Expect(unionFind.Connected("a", "b")).To(BeTrue())
+				Expect(unionFind.Connected("c", "d")).To(BeTrue())
+				Expect(unionFind.Connected("a", "c")).To(BeFalse())
+				Expect(unionFind.SetSize("a")).To(Equal(2))
+				Expect(unionFind.SetSize("e")).To(Equal(3))
+			})
+
+			It("has a common representative for a group", func() {
+				first, _ := unionFind.Find("c")
+				second, _ := unionFind.Find("d")
+				Expect(first).To(Equal(second))
+			})
+
+			It("does not join items of the same group", func() {
+				Expect(unionFind.Union("c", "d")).To(BeFalse())
+				Expect(unionFind.GroupCount()).To(Equal(2))
+			})
+
+			It("iterates over the groups", func() {
+				var groups [][]string
+				for group := range unionFind.Groups() {
+					slices.Sort(group)
+					groups = append(groups, group)
+				}
+				Expect(groups).To(ConsistOf(
+					[]string{"a", "b"},
+					[]string{"c", "d", "e"},
+				))
+			})
+
+			When("cleared", func() {
+				BeforeEach(func() {
+					unionFind.Clear()
+				})
+
+				It("becomes empty", func() {
+					Expect(unionFind.IsEmpty()).To(BeTrue())
+					Expect(unionFind.GroupCount()).To(BeZero())
+					Expect(unionFind.Contains("a")).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("marshaling", func() {
+		It("marshals to sorted groups", func() {
+			unionFind := ds.NewUnionFind[int](0)
+			unionFind.Union(5, 3)
+			unionFind.Union(1, 4)
+			unionFind.Add(2)
+			data, err := json.Marshal(unionFind)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`[[1,4],[2],[3,5]]`))
+		})
+
+		It("unmarshals into a zero value", func() {
+			var unionFind ds.UnionFind[string]
+			Expect(json.Unmarshal([]byte(`[["a","b","c"],["d"]]`), &unionFind)).To(Succeed())
+			Expect(unionFind.GroupCount()).To(Equal(2))
+			Expect(unionFind.Connected("a", "c")).To(BeTrue())
+			Expect(unionFind.Connected("a", "d")).To(BeFalse())
+		})
+
+		It("fails to unmarshal overlapping or empty groups", func() {
+			var unionFind ds.UnionFind[string]
+			Expect(json.Unmarshal([]byte(`[["a"],["a","b"]]`), &unionFind)).ToNot(Succeed())
+			Expect(json.Unmarshal([]byte(`[[]]`), &unionFind)).ToNot(Succeed())
+		})
+
+		It("round-trips through gob", func() {
+			source := ds.NewUnionFind[int](0)
+			source.Union(1, 2)
+			source.Add(3)
+			var restored ds.UnionFind[int]
+			gobRoundTrip(source, &restored)
+			Expect(restored.Connected(1, 2)).To(BeTrue())
+			Expect(restored.SetSize(3)).To(Equal(1))
+		})
+	})
+})
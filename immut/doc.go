@@ -0,0 +1,24 @@
+// Package immut provides persistent (immutable) data structures.
+//
+// Operations that would modify a collection instead return a new version of
+// it, leaving the original unchanged. The new version shares most of its
+// internal structure with the original, so updates take O(log n) time and
+// memory instead of requiring a full copy. This makes it cheap to keep
+// older versions around, for example for undo history, and safe to share
+// versions between goroutines without synchronization.
+//
+// The zero value of each collection is an empty collection that is ready to
+// use.
+//
+// When many changes need to be applied at once, a transient can be obtained
+// through the Transient method. Transients are mutable builders that modify
+// the nodes they own in place and turn back into a persistent collection
+// through their Persistent method. Transients are not safe for concurrent
+// use and must not be used after Persistent has been called.
+package immut
+
+// owner marks the nodes that have been created by a specific transient and
+// can therefore be modified in place by it.
+type owner struct {
+	_ byte // ensures distinct allocations have distinct addresses
+}
@@ -0,0 +1,57 @@
+package immut_test
+
+import (
+	"fmt"
+
+	"github.com/mokiat/gog/immut"
+)
+
+func ExampleMap() {
+	var config immut.Map[string, int]
+	config = config.Set("width", 800).Set("height", 600)
+
+	snapshot := config
+	config = config.Set("width", 1024)
+
+	width, _ := snapshot.Get("width")
+	fmt.Println(width)
+	width, _ = config.Get("width")
+	fmt.Println(width)
+
+	// Output:
+	// 800
+	// 1024
+}
+
+func ExampleVector() {
+	var history immut.Vector[string]
+	history = history.Append("draw line")
+	undo := history
+	history = history.Append("draw circle")
+
+	fmt.Println(history.Items())
+	fmt.Println(undo.Items())
+
+	// Output:
+	// [draw line draw circle]
+	// [draw line]
+}
+
+func ExampleVector_Transient() {
+	transient := immut.Vector[int]{}.Transient()
+	for i := range 5 {
+		transient.Append(i * i)
+	}
+	squares := transient.Persistent()
+
+	for index, item := range squares.Indexed() {
+		fmt.Println(index, item)
+	}
+
+	// Output:
+	// 0 0
+	// 1 1
+	// 2 4
+	// 3 9
+	// 4 16
+}
@@ -0,0 +1,419 @@
+package immut
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+const (
+	mapBits     = 5
+	mapMask     = 1<<mapBits - 1
+	mapMaxShift = 64
+)
+
+var mapSeed = maphash.MakeSeed()
+
+// MapFromMap creates a new Map that contains the entries of the specified
+// Go map.
+func MapFromMap[K comparable, V any](m map[K]V) Map[K, V] {
+	return MapFromSeq(func(yield func(K, V) bool) {
+		for key, value := range m {
+			if !yield(key, value) {
+				return
+			}
+		}
+	})
+}
+
+// MapFromSeq creates a new Map that contains the entries of the specified
+// sequence. If a key appears multiple times, the last value wins.
+func MapFromSeq[K comparable, V any](src iter.Seq2[K, V]) Map[K, V] {
+	var result Map[K, V]
+	transient := result.Transient()
+	for key, value := range src {
+		transient.Set(key, value)
+	}
+	return transient.Persistent()
+}
+
+// Map is a persistent hash map. It is implemented as a hash array mapped
+// trie (HAMT), which makes Get, Set and Delete take O(log32 n) time.
+//
+// A Map is a value type and the zero value is an empty Map.
+type Map[K comparable, V any] struct {
+	size int
+	root *mapNode[K, V]
+}
+
+// Size returns the number of entries in this Map.
+func (m Map[K, V]) Size() int {
+	return m.size
+}
+
+// IsEmpty returns whether this Map has no entries.
+func (m Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Get returns the value that is assigned to the specified key. The second
+// return value indicates whether the key was present.
+func (m Map[K, V]) Get(key K) (V, bool) {
+	if m.root == nil {
+		var zero V
+		return zero, false
+	}
+	return m.root.get(maphash.Comparable(mapSeed, key), 0, key)
+}
+
+// Has returns whether the specified key is present in this Map.
+func (m Map[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set returns a new Map in which the specified key is assigned the
+// specified value.
+func (m Map[K, V]) Set(key K, value V) Map[K, V] {
+	(&m).set(nil, key, value)
+	return m
+}
+
+// Delete returns a new Map without the specified key. If the key is not
+// present, the Map is returned unchanged.
+func (m Map[K, V]) Delete(key K) Map[K, V] {
+	(&m).delete(nil, key)
+	return m
+}
+
+// Keys returns a sequence over the keys of this Map.
+//
+// Note: The keys are returned in an unspecified order.
+func (m Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence over the values of this Map.
+//
+// Note: The values are returned in an unspecified order.
+func (m Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence over the entries of this Map.
+//
+// Note: The entries are returned in an unspecified order.
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.walk(yield)
+		}
+	}
+}
+
+// Transient returns a mutable TransientMap that starts with the entries of
+// this Map. The Map itself is not affected by changes to the TransientMap.
+func (m Map[K, V]) Transient() *TransientMap[K, V] {
+	return &TransientMap[K, V]{
+		m:     m,
+		owner: &owner{},
+	}
+}
+
+func (m *Map[K, V]) set(editor *owner, key K, value V) {
+	slot := mapSlot[K, V]{
+		hash:  maphash.Comparable(mapSeed, key),
+		key:   key,
+		value: value,
+	}
+	if m.root == nil {
+		m.root = &mapNode[K, V]{
+			owner: editor,
+		}
+	}
+	var added bool
+	m.root, added = m.root.set(editor, 0, slot)
+	if added {
+		m.size++
+	}
+}
+
+func (m *Map[K, V]) delete(editor *owner, key K) {
+	if m.root == nil {
+		return
+	}
+	root, removed := m.root.delete(editor, maphash.Comparable(mapSeed, key), 0, key)
+	if !removed {
+		return
+	}
+	m.root = root
+	m.size--
+	if m.size == 0 {
+		m.root = nil
+	}
+}
+
+// TransientMap is a mutable builder for a Map. It modifies the nodes that
+// it owns in place, which makes batches of changes considerably faster than
+// performing them on a Map one at a time.
+type TransientMap[K comparable, V any] struct {
+	m     Map[K, V]
+	owner *owner
+}
+
+// Size returns the number of entries in this TransientMap.
+func (t *TransientMap[K, V]) Size() int {
+	t.checkEditable()
+	return t.m.size
+}
+
+// Get returns the value that is assigned to the specified key. The second
+// return value indicates whether the key was present.
+func (t *TransientMap[K, V]) Get(key K) (V, bool) {
+	t.checkEditable()
+	return t.m.Get(key)
+}
+
+// Has returns whether the specified key is present in this TransientMap.
+func (t *TransientMap[K, V]) Has(key K) bool {
+	t.checkEditable()
+	return t.m.Has(key)
+}
+
+// Set assigns the specified value to the specified key.
+func (t *TransientMap[K, V]) Set(key K, value V) {
+	t.checkEditable()
+	t.m.set(t.owner, key, value)
+}
+
+// Delete removes the specified key from this TransientMap.
+func (t *TransientMap[K, V]) Delete(key K) {
+	t.checkEditable()
+	t.m.delete(t.owner, key)
+}
+
+// Persistent returns a Map that contains the entries of this TransientMap.
+// The TransientMap must not be used afterwards.
+func (t *TransientMap[K, V]) Persistent() Map[K, V] {
+	t.checkEditable()
+	t.owner = nil
+	return t.m
+}
+
+func (t *TransientMap[K, V]) checkEditable() {
+	if t.owner == nil {
+		panic("immut: transient used after persistent")
+	}
+}
+
+// mapNode is a node of the hash array mapped trie. Each node consumes
+// mapBits bits of the hash and keeps a bitmap of the positions that are
+// occupied, with the slots for them stored densely. Once all hash bits have
+// been consumed, entries with colliding hashes are stored in a collision
+// node, whose slots are searched linearly instead.
+type mapNode[K comparable, V any] struct {
+	owner  *owner
+	bitmap uint32
+	slots  []mapSlot[K, V]
+}
+
+// mapSlot is either an entry or a reference to a child node.
+type mapSlot[K comparable, V any] struct {
+	child *mapNode[K, V]
+	hash  uint64
+	key   K
+	value V
+}
+
+func (n *mapNode[K, V]) editable(editor *owner) *mapNode[K, V] {
+	if editor != nil && n.owner == editor {
+		return n
+	}
+	return &mapNode[K, V]{
+		owner:  editor,
+		bitmap: n.bitmap,
+		slots:  slices.Clone(n.slots),
+	}
+}
+
+func (n *mapNode[K, V]) position(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & mapMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *mapNode[K, V]) get(hash uint64, shift uint, key K) (V, bool) {
+	for node := n; ; shift += mapBits {
+		if shift >= mapMaxShift {
+			for _, slot := range node.slots {
+				if slot.key == key {
+					return slot.value, true
+				}
+			}
+			break
+		}
+		bit, index := node.position(hash, shift)
+		if node.bitmap&bit == 0 {
+			break
+		}
+		slot := &node.slots[index]
+		if slot.child == nil {
+			if slot.hash == hash && slot.key == key {
+				return slot.value, true
+			}
+			break
+		}
+		node = slot.child
+	}
+	var zero V
+	return zero, false
+}
+
+func (n *mapNode[K, V]) set(editor *owner, shift uint, slot mapSlot[K, V]) (*mapNode[K, V], bool) {
+	if shift >= mapMaxShift {
+		result := n.editable(editor)
+		for i := range result.slots {
+			if result.slots[i].key == slot.key {
+				result.slots[i] = slot
+				return result, false
+			}
+		}
+		result.slots = append(result.slots, slot)
+		return result, true
+	}
+
+	bit, index := n.position(slot.hash, shift)
+	if n.bitmap&bit == 0 {
+		result := n.editable(editor)
+		result.bitmap |= bit
+		result.slots = slices.Insert(result.slots, index, slot)
+		return result, true
+	}
+
+	existing := n.slots[index]
+	if existing.child != nil {
+		child, added := existing.child.set(editor, shift+mapBits, slot)
+		result := n.editable(editor)
+		result.slots[index].child = child
+		return result, added
+	}
+	result := n.editable(editor)
+	if existing.hash == slot.hash && existing.key == slot.key {
+		result.slots[index] = slot
+		return result, false
+	}
+	result.slots[index] = mapSlot[K, V]{
+		child: newMapNode(editor, shift+mapBits, existing, slot),
+	}
+	return result, true
+}
+
+func (n *mapNode[K, V]) delete(editor *owner, hash uint64, shift uint, key K) (*mapNode[K, V], bool) {
+	if shift >= mapMaxShift {
+		index := slices.IndexFunc(n.slots, func(slot mapSlot[K, V]) bool {
+			return slot.key == key
+		})
+		if index < 0 {
+			return n, false
+		}
+		result := n.editable(editor)
+		result.slots = slices.Delete(result.slots, index, index+1)
+		return result, true
+	}
+
+	bit, index := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	existing := n.slots[index]
+	if existing.child == nil {
+		if existing.hash != hash || existing.key != key {
+			return n, false
+		}
+		result := n.editable(editor)
+		result.bitmap &^= bit
+		result.slots = slices.Delete(result.slots, index, index+1)
+		return result, true
+	}
+
+	child, removed := existing.child.delete(editor, hash, shift+mapBits, key)
+	if !removed {
+		return n, false
+	}
+	result := n.editable(editor)
+	if inlined, ok := child.single(); ok {
+		// Keep the trie compact by pulling single entries up a level.
+		result.slots[index] = inlined
+	} else {
+		result.slots[index].child = child
+	}
+	return result, true
+}
+
+// single returns the only entry of this node, if the node holds exactly
+// one entry and no children.
+func (n *mapNode[K, V]) single() (mapSlot[K, V], bool) {
+	if len(n.slots) != 1 || n.slots[0].child != nil {
+		return mapSlot[K, V]{}, false
+	}
+	return n.slots[0], true
+}
+
+func (n *mapNode[K, V]) walk(yield func(K, V) bool) bool {
+	for i := range n.slots {
+		slot := &n.slots[i]
+		if slot.child != nil {
+			if !slot.child.walk(yield) {
+				return false
+			}
+			continue
+		}
+		if !yield(slot.key, slot.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// newMapNode creates a node that holds the two specified entries, which
+// have collided at the previous level.
+func newMapNode[K comparable, V any](editor *owner, shift uint, first, second mapSlot[K, V]) *mapNode[K, V] {
+	if shift >= mapMaxShift {
+		return &mapNode[K, V]{
+			owner: editor,
+			slots: []mapSlot[K, V]{first, second},
+		}
+	}
+	firstPosition := (first.hash >> shift) & mapMask
+	secondPosition := (second.hash >> shift) & mapMask
+	if firstPosition == secondPosition {
+		return &mapNode[K, V]{
+			owner:  editor,
+			bitmap: 1 << firstPosition,
+			slots: []mapSlot[K, V]{
+				{child: newMapNode(editor, shift+mapBits, first, second)},
+			},
+		}
+	}
+	if firstPosition > secondPosition {
+		first, second = second, first
+	}
+	return &mapNode[K, V]{
+		owner:  editor,
+		bitmap: 1<<firstPosition | 1<<secondPosition,
+		slots:  []mapSlot[K, V]{first, second},
+	}
+}
@@ -0,0 +1,149 @@
+package immut
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("mapNode", func() {
+	// collidingSlot creates an entry whose hash is shared with all other
+	// entries that have the same key modulo three, which forces the use of
+	// collision nodes.
+	collidingSlot := func(key, value int) mapSlot[int, int] {
+		return mapSlot[int, int]{
+			hash:  uint64(key % 3),
+			key:   key,
+			value: value,
+		}
+	}
+
+	setAll := func(root *mapNode[int, int], editor *owner, count, offset int) *mapNode[int, int] {
+		for key := range count {
+			var added bool
+			root, added = root.set(editor, 0, collidingSlot(key, key+offset))
+			Expect(added).To(BeTrue())
+		}
+		return root
+	}
+
+	get := func(root *mapNode[int, int], key int) (int, bool) {
+		return root.get(uint64(key%3), 0, key)
+	}
+
+	collect := func(root *mapNode[int, int]) map[int]int {
+		result := make(map[int]int)
+		root.walk(func(key, value int) bool {
+			result[key] = value
+			return true
+		})
+		return result
+	}
+
+	depth := func(root *mapNode[int, int], key int) int {
+		result := 0
+		for node, shift := root, uint(0); shift < mapMaxShift; shift += mapBits {
+			bit, index := node.position(uint64(key%3), shift)
+			if node.bitmap&bit == 0 || node.slots[index].child == nil {
+				return result
+			}
+			node = node.slots[index].child
+			result++
+		}
+		return result
+	}
+
+	const count = 30
+
+	var root *mapNode[int, int]
+
+	BeforeEach(func() {
+		root = setAll(&mapNode[int, int]{}, nil, count, 100)
+	})
+
+	It("stores colliding entries in collision nodes", func() {
+		Expect(depth(root, 0)).To(Equal(mapMaxShift/mapBits + 1))
+		for key := range count {
+			value, ok := get(root, key)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(key + 100))
+		}
+	})
+
+	It("does not find missing keys with a colliding hash", func() {
+		_, ok := get(root, count)
+		Expect(ok).To(BeFalse())
+		_, removed := root.delete(nil, uint64(count%3), 0, count)
+		Expect(removed).To(BeFalse())
+	})
+
+	It("overwrites colliding entries without changing the original", func() {
+		updated, added := root.set(nil, 0, collidingSlot(4, -4))
+		Expect(added).To(BeFalse())
+		value, ok := get(updated, 4)
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(-4))
+		Expect(collect(updated)).To(HaveLen(count))
+
+		value, ok = get(root, 4)
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(104))
+	})
+
+	It("iterates over all colliding entries", func() {
+		entries := collect(root)
+		Expect(entries).To(HaveLen(count))
+		for key := range count {
+			Expect(entries).To(HaveKeyWithValue(key, key+100))
+		}
+	})
+
+	It("stops iteration early", func() {
+		visited := 0
+		completed := root.walk(func(key, value int) bool {
+			visited++
+			return visited < 5
+		})
+		Expect(completed).To(BeFalse())
+		Expect(visited).To(Equal(5))
+	})
+
+	It("deletes colliding entries and collapses the nodes", func() {
+		original := root
+		for key := range count {
+			var removed bool
+			root, removed = root.delete(nil, uint64(key%3), 0, key)
+			Expect(removed).To(BeTrue())
+			_, ok := get(root, key)
+			Expect(ok).To(BeFalse())
+			for other := key + 1; other < count; other++ {
+				value, ok := get(root, other)
+				Expect(ok).To(BeTrue())
+				Expect(value).To(Equal(other + 100))
+			}
+			Expect(collect(root)).To(HaveLen(count - key - 1))
+		}
+		Expect(root.slots).To(BeEmpty())
+		Expect(collect(original)).To(HaveLen(count))
+	})
+
+	It("pulls the last colliding entry up to the root", func() {
+		for key := 3; key < count; key += 3 {
+			root, _ = root.delete(nil, 0, 0, key)
+		}
+		Expect(depth(root, 0)).To(BeZero())
+		value, ok := get(root, 0)
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(100))
+	})
+
+	It("modifies owned collision nodes in place", func() {
+		editor := &owner{}
+		transient := setAll(&mapNode[int, int]{owner: editor}, editor, count, 0)
+		updated, added := transient.set(editor, 0, collidingSlot(7, -7))
+		Expect(added).To(BeFalse())
+		Expect(updated).To(BeIdenticalTo(transient))
+		value, ok := get(transient, 7)
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(-7))
+	})
+})
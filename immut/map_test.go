@@ -0,0 +1,177 @@
+package immut_test
+
+import (
+	"maps"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/immut"
+)
+
+var _ = Describe("Map", func() {
+	var m immut.Map[int, string]
+
+	BeforeEach(func() {
+		m = immut.Map[int, string]{}
+	})
+
+	It("is empty by default", func() {
+		Expect(m.IsEmpty()).To(BeTrue())
+		Expect(m.Size()).To(BeZero())
+		Expect(m.Has(1)).To(BeFalse())
+		Expect(maps.Collect(m.All())).To(BeEmpty())
+	})
+
+	It("ignores deletion of missing keys", func() {
+		m = m.Delete(1)
+		Expect(m.IsEmpty()).To(BeTrue())
+	})
+
+	When("entries are set", func() {
+		BeforeEach(func() {
+			m = m.Set(1, "one").Set(2, "two").Set(3, "three")
+		})
+
+		It("has the correct size", func() {
+			Expect(m.IsEmpty()).To(BeFalse())
+			Expect(m.Size()).To(Equal(3))
+		})
+
+		It("is possible to get values", func() {
+			value, ok := m.Get(2)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("two"))
+			_, ok = m.Get(4)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("replaces existing values without affecting the original", func() {
+			updated := m.Set(2, "TWO")
+			Expect(updated.Size()).To(Equal(3))
+			value, _ := updated.Get(2)
+			Expect(value).To(Equal("TWO"))
+			value, _ = m.Get(2)
+			Expect(value).To(Equal("two"))
+		})
+
+		It("deletes entries without affecting the original", func() {
+			updated := m.Delete(2)
+			Expect(updated.Size()).To(Equal(2))
+			Expect(updated.Has(2)).To(BeFalse())
+			Expect(m.Has(2)).To(BeTrue())
+		})
+
+		It("is possible to iterate the entries", func() {
+			Expect(maps.Collect(m.All())).To(Equal(map[int]string{
+				1: "one", 2: "two", 3: "three",
+			}))
+			Expect(slices.Collect(m.Keys())).To(ConsistOf(1, 2, 3))
+			Expect(slices.Collect(m.Values())).To(ConsistOf("one", "two", "three"))
+		})
+	})
+
+	When("many entries are set", func() {
+		const count = 20000
+
+		BeforeEach(func() {
+			for i := range count {
+				m = m.Set(i, "value")
+			}
+		})
+
+		It("contains all of them", func() {
+			Expect(m.Size()).To(Equal(count))
+			for i := range count {
+				Expect(m.Has(i)).To(BeTrue())
+			}
+			Expect(m.Has(count)).To(BeFalse())
+			Expect(maps.Collect(m.All())).To(HaveLen(count))
+		})
+
+		It("is possible to delete all of them", func() {
+			original := m
+			for i := range count {
+				m = m.Delete(i)
+				Expect(m.Has(i)).To(BeFalse())
+			}
+			Expect(m.IsEmpty()).To(BeTrue())
+			Expect(original.Size()).To(Equal(count))
+			Expect(original.Has(count - 1)).To(BeTrue())
+		})
+
+		It("keeps the remaining entries when deleting some", func() {
+			for i := 0; i < count; i += 2 {
+				m = m.Delete(i)
+			}
+			Expect(m.Size()).To(Equal(count / 2))
+			for i := range count {
+				Expect(m.Has(i)).To(Equal(i%2 == 1))
+			}
+		})
+	})
+
+	When("using a transient", func() {
+		var transient *immut.TransientMap[int, string]
+
+		BeforeEach(func() {
+			m = immut.MapFromMap(map[int]string{1: "one", 2: "two"})
+			transient = m.Transient()
+		})
+
+		It("is possible to apply batch changes", func() {
+			for i := range 1000 {
+				transient.Set(i, "value")
+			}
+			transient.Delete(0)
+			transient.Delete(-1)
+			Expect(transient.Size()).To(Equal(999))
+			Expect(transient.Has(0)).To(BeFalse())
+
+			result := transient.Persistent()
+			Expect(result.Size()).To(Equal(999))
+			value, ok := result.Get(2)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("value"))
+		})
+
+		It("does not affect the original", func() {
+			transient.Set(1, "changed")
+			transient.Delete(2)
+			for i := range 1000 {
+				transient.Set(i+10, "value")
+			}
+			Expect(maps.Collect(m.All())).To(Equal(map[int]string{
+				1: "one", 2: "two",
+			}))
+		})
+
+		It("does not affect versions derived from the result", func() {
+			for i := range 1000 {
+				transient.Set(i+10, "value")
+			}
+			result := transient.Persistent()
+			again := result.Transient()
+			for i := range 1000 {
+				again.Set(i+10, "changed")
+			}
+			again.Delete(1)
+			value, _ := result.Get(500)
+			Expect(value).To(Equal("value"))
+			Expect(result.Has(1)).To(BeTrue())
+		})
+
+		It("panics when used after becoming persistent", func() {
+			transient.Persistent()
+			Expect(func() { transient.Set(3, "three") }).To(Panic())
+		})
+	})
+
+	It("supports string keys", func() {
+		words := immut.MapFromSeq(maps.All(map[string]int{"a": 1, "b": 2}))
+		value, ok := words.Get("b")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(2))
+	})
+})
@@ -0,0 +1,13 @@
+package immut_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestImmut(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Immutable Data Structures Suite")
+}
@@ -0,0 +1,392 @@
+package immut
+
+import (
+	"iter"
+	"slices"
+)
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// VectorFromSlice creates a new Vector that contains the items of the
+// specified slice.
+func VectorFromSlice[T any](items []T) Vector[T] {
+	return VectorFromSeq(slices.Values(items))
+}
+
+// VectorFromSeq creates a new Vector that contains the items of the
+// specified sequence.
+func VectorFromSeq[T any](src iter.Seq[T]) Vector[T] {
+	var result Vector[T]
+	transient := result.Transient()
+	for item := range src {
+		transient.Append(item)
+	}
+	return transient.Persistent()
+}
+
+// Vector is a persistent indexed sequence of items. It is implemented as a
+// 32-way trie with a tail buffer, which makes Get, Set and Pop take
+// O(log32 n) time and Append take amortized O(1) time.
+//
+// A Vector is a value type and the zero value is an empty Vector.
+type Vector[T any] struct {
+	size      int
+	shift     uint
+	root      *vectorNode[T]
+	tail      []T
+	tailOwner *owner
+}
+
+// Size returns the number of items in this Vector.
+func (v Vector[T]) Size() int {
+	return v.size
+}
+
+// IsEmpty returns whether this Vector has no items.
+func (v Vector[T]) IsEmpty() bool {
+	return v.size == 0
+}
+
+// Get returns the item at the specified index.
+//
+// This method panics if the index is outside the Vector bounds.
+func (v Vector[T]) Get(index int) T {
+	if index < 0 || index >= v.size {
+		panic("immut: vector index out of range")
+	}
+	return v.leafFor(index)[index&vectorMask]
+}
+
+// Set returns a new Vector in which the item at the specified index is
+// replaced with the specified value.
+//
+// This method panics if the index is outside the Vector bounds.
+func (v Vector[T]) Set(index int, value T) Vector[T] {
+	(&v).set(nil, index, value)
+	return v
+}
+
+// Append returns a new Vector with the specified item added to the end.
+func (v Vector[T]) Append(item T) Vector[T] {
+	(&v).append(nil, item)
+	return v
+}
+
+// Pop returns a new Vector with the last item removed.
+//
+// This method panics if the Vector is empty.
+func (v Vector[T]) Pop() Vector[T] {
+	(&v).pop(nil)
+	return v
+}
+
+// All returns a sequence over the items of this Vector, in order.
+func (v Vector[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range v.Indexed() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Indexed returns a sequence over the items of this Vector, in order,
+// paired with their indices.
+func (v Vector[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for offset := 0; offset < v.size; offset += vectorWidth {
+			leaf := v.leafFor(offset)
+			for i, item := range leaf {
+				if !yield(offset+i, item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Items returns a new slice that contains all items of this Vector.
+func (v Vector[T]) Items() []T {
+	result := make([]T, 0, v.size)
+	for _, item := range v.Indexed() {
+		result = append(result, item)
+	}
+	return result
+}
+
+// Transient returns a mutable TransientVector that starts with the items of
+// this Vector. The Vector itself is not affected by changes to the
+// TransientVector.
+func (v Vector[T]) Transient() *TransientVector[T] {
+	return &TransientVector[T]{
+		vector: v,
+		owner:  &owner{},
+	}
+}
+
+func (v *Vector[T]) tailOffset() int {
+	return v.size - len(v.tail)
+}
+
+func (v *Vector[T]) leafFor(index int) []T {
+	if index >= v.tailOffset() {
+		return v.tail
+	}
+	node := v.root
+	for level := v.shift; level > 0; level -= vectorBits {
+		node = node.children[(index>>level)&vectorMask]
+	}
+	return node.values
+}
+
+func (v *Vector[T]) set(editor *owner, index int, value T) {
+	if index < 0 || index >= v.size {
+		panic("immut: vector index out of range")
+	}
+	if index >= v.tailOffset() {
+		v.editTail(editor, len(v.tail))
+		v.tail[index&vectorMask] = value
+		return
+	}
+	v.root = v.setInNode(editor, v.root, v.shift, index, value)
+}
+
+func (v *Vector[T]) setInNode(editor *owner, node *vectorNode[T], level uint, index int, value T) *vectorNode[T] {
+	result := node.editable(editor)
+	if level == 0 {
+		result.values[index&vectorMask] = value
+		return result
+	}
+	childIndex := (index >> level) & vectorMask
+	result.children[childIndex] = v.setInNode(editor, node.children[childIndex], level-vectorBits, index, value)
+	return result
+}
+
+func (v *Vector[T]) append(editor *owner, item T) {
+	if len(v.tail) < vectorWidth {
+		v.editTail(editor, len(v.tail)+1)
+		v.tail[len(v.tail)-1] = item
+		v.size++
+		return
+	}
+
+	leaf := &vectorNode[T]{
+		values: v.tail,
+	}
+	if editor != nil && v.tailOwner == editor {
+		leaf.owner = editor
+	}
+	switch {
+	case v.root == nil:
+		v.root = &vectorNode[T]{
+			owner:    editor,
+			children: []*vectorNode[T]{leaf},
+		}
+		v.shift = vectorBits
+	case (v.size >> vectorBits) > (1 << v.shift):
+		v.root = &vectorNode[T]{
+			owner:    editor,
+			children: []*vectorNode[T]{v.root, newVectorPath(editor, v.shift, leaf)},
+		}
+		v.shift += vectorBits
+	default:
+		v.root = v.pushLeaf(editor, v.root, v.shift, leaf)
+	}
+
+	v.tail = nil
+	v.tailOwner = nil
+	v.editTail(editor, 1)
+	v.tail[0] = item
+	v.size++
+}
+
+// editTail resizes the tail to the specified length, making sure that it
+// can be modified by the specified editor. Persistent edits always work on a
+// fresh copy, while transient edits copy the tail once, with full capacity,
+// and modify it in place from then on.
+func (v *Vector[T]) editTail(editor *owner, length int) {
+	if editor != nil && v.tailOwner == editor {
+		if length < len(v.tail) {
+			clear(v.tail[length:])
+		}
+		v.tail = v.tail[:length]
+		return
+	}
+	capacity := length
+	if editor != nil {
+		capacity = vectorWidth
+	}
+	tail := make([]T, length, capacity)
+	copy(tail, v.tail)
+	v.tail = tail
+	v.tailOwner = editor
+}
+
+func (v *Vector[T]) pushLeaf(editor *owner, node *vectorNode[T], level uint, leaf *vectorNode[T]) *vectorNode[T] {
+	result := node.editable(editor)
+	childIndex := ((v.size - 1) >> level) & vectorMask
+	var child *vectorNode[T]
+	switch {
+	case level == vectorBits:
+		child = leaf
+	case childIndex < len(node.children):
+		child = v.pushLeaf(editor, node.children[childIndex], level-vectorBits, leaf)
+	default:
+		child = newVectorPath(editor, level-vectorBits, leaf)
+	}
+	if childIndex < len(result.children) {
+		result.children[childIndex] = child
+	} else {
+		result.children = append(result.children, child)
+	}
+	return result
+}
+
+func (v *Vector[T]) pop(editor *owner) {
+	switch {
+	case v.size == 0:
+		panic("immut: pop from empty vector")
+	case v.size == 1:
+		*v = Vector[T]{}
+		return
+	case len(v.tail) > 1:
+		v.editTail(editor, len(v.tail)-1)
+		v.size--
+		return
+	}
+
+	v.tail = v.leafFor(v.size - 2)
+	v.tailOwner = nil // shared with the leaf that is being removed
+	root := v.popLeaf(editor, v.root, v.shift)
+	switch {
+	case root == nil:
+		v.root = nil
+		v.shift = 0
+	case v.shift > vectorBits && len(root.children) == 1:
+		v.root = root.children[0]
+		v.shift -= vectorBits
+	default:
+		v.root = root
+	}
+	v.size--
+}
+
+func (v *Vector[T]) popLeaf(editor *owner, node *vectorNode[T], level uint) *vectorNode[T] {
+	childIndex := ((v.size - 2) >> level) & vectorMask
+	if level > vectorBits {
+		child := v.popLeaf(editor, node.children[childIndex], level-vectorBits)
+		if child == nil && childIndex == 0 {
+			return nil
+		}
+		result := node.editable(editor)
+		if child == nil {
+			result.children[childIndex] = nil
+			result.children = result.children[:childIndex]
+		} else {
+			result.children[childIndex] = child
+		}
+		return result
+	}
+	if childIndex == 0 {
+		return nil
+	}
+	result := node.editable(editor)
+	result.children[childIndex] = nil
+	result.children = result.children[:childIndex]
+	return result
+}
+
+// TransientVector is a mutable builder for a Vector. It modifies the nodes
+// that it owns in place, which makes batches of changes considerably faster
+// than performing them on a Vector one at a time.
+type TransientVector[T any] struct {
+	vector Vector[T]
+	owner  *owner
+}
+
+// Size returns the number of items in this TransientVector.
+func (t *TransientVector[T]) Size() int {
+	t.checkEditable()
+	return t.vector.size
+}
+
+// Get returns the item at the specified index.
+//
+// This method panics if the index is outside the TransientVector bounds.
+func (t *TransientVector[T]) Get(index int) T {
+	t.checkEditable()
+	return t.vector.Get(index)
+}
+
+// Set replaces the item at the specified index with the specified value.
+//
+// This method panics if the index is outside the TransientVector bounds.
+func (t *TransientVector[T]) Set(index int, value T) {
+	t.checkEditable()
+	t.vector.set(t.owner, index, value)
+}
+
+// Append adds the specified item to the end of this TransientVector.
+func (t *TransientVector[T]) Append(item T) {
+	t.checkEditable()
+	t.vector.append(t.owner, item)
+}
+
+// Pop removes the last item of this TransientVector.
+//
+// This method panics if the TransientVector is empty.
+func (t *TransientVector[T]) Pop() {
+	t.checkEditable()
+	t.vector.pop(t.owner)
+}
+
+// Persistent returns a Vector that contains the items of this
+// TransientVector. The TransientVector must not be used afterwards.
+func (t *TransientVector[T]) Persistent() Vector[T] {
+	t.checkEditable()
+	t.owner = nil
+	result := t.vector
+	result.tail = slices.Clip(result.tail)
+	result.tailOwner = nil
+	return result
+}
+
+func (t *TransientVector[T]) checkEditable() {
+	if t.owner == nil {
+		panic("immut: transient used after persistent")
+	}
+}
+
+type vectorNode[T any] struct {
+	owner    *owner
+	children []*vectorNode[T]
+	values   []T
+}
+
+// editable returns the node itself if it is owned by the specified editor
+// and a copy owned by the editor otherwise.
+func (n *vectorNode[T]) editable(editor *owner) *vectorNode[T] {
+	if editor != nil && n.owner == editor {
+		return n
+	}
+	return &vectorNode[T]{
+		owner:    editor,
+		children: slices.Clone(n.children),
+		values:   slices.Clone(n.values),
+	}
+}
+
+func newVectorPath[T any](editor *owner, level uint, leaf *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return leaf
+	}
+	return &vectorNode[T]{
+		owner:    editor,
+		children: []*vectorNode[T]{newVectorPath(editor, level-vectorBits, leaf)},
+	}
+}
@@ -0,0 +1,168 @@
+package immut_test
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/immut"
+	"github.com/mokiat/gog/seq"
+)
+
+var _ = Describe("Vector", func() {
+	var vector immut.Vector[int]
+
+	BeforeEach(func() {
+		vector = immut.Vector[int]{}
+	})
+
+	It("is empty by default", func() {
+		Expect(vector.IsEmpty()).To(BeTrue())
+		Expect(vector.Size()).To(BeZero())
+		Expect(vector.Items()).To(BeEmpty())
+	})
+
+	It("panics when accessing out of bounds", func() {
+		Expect(func() { vector.Get(0) }).To(Panic())
+		Expect(func() { vector.Set(0, 1) }).To(Panic())
+		Expect(func() { vector.Pop() }).To(Panic())
+	})
+
+	// sizes cover the tail, a single level trie, root growth and
+	// multiple levels
+	for _, size := range []int{1, 31, 32, 33, 64, 65, 1024, 1025, 1056, 1057, 40000} {
+		When("items are appended", func() {
+			var expected []int
+
+			BeforeEach(func() {
+				expected = slices.Collect(seq.Times(size))
+				for _, item := range expected {
+					vector = vector.Append(item)
+				}
+			})
+
+			It("has the correct size", func() {
+				Expect(vector.IsEmpty()).To(BeFalse())
+				Expect(vector.Size()).To(Equal(size))
+			})
+
+			It("is possible to get the items", func() {
+				for index, item := range expected {
+					Expect(vector.Get(index)).To(Equal(item))
+				}
+			})
+
+			It("is possible to iterate the items", func() {
+				Expect(slices.Collect(vector.All())).To(Equal(expected))
+				Expect(vector.Items()).To(Equal(expected))
+				for index, item := range vector.Indexed() {
+					Expect(item).To(Equal(expected[index]))
+				}
+			})
+
+			It("is possible to set items without affecting the original", func() {
+				original := vector
+				for _, index := range []int{0, size / 2, size - 1} {
+					vector = vector.Set(index, -1)
+					Expect(vector.Get(index)).To(Equal(-1))
+				}
+				Expect(original.Items()).To(Equal(expected))
+			})
+
+			It("is possible to pop all items without affecting the original", func() {
+				original := vector
+				for i := size - 1; i >= 0; i-- {
+					vector = vector.Pop()
+					Expect(vector.Size()).To(Equal(i))
+					if i > 0 {
+						Expect(vector.Get(i - 1)).To(Equal(expected[i-1]))
+					}
+				}
+				Expect(vector.IsEmpty()).To(BeTrue())
+				Expect(original.Items()).To(Equal(expected))
+			})
+
+			It("is possible to append after popping", func() {
+				vector = vector.Pop().Append(-1).Append(-2)
+				Expect(vector.Size()).To(Equal(size + 1))
+				Expect(vector.Get(size - 1)).To(Equal(-1))
+				Expect(vector.Get(size)).To(Equal(-2))
+				if size > 1 {
+					Expect(vector.Get(size - 2)).To(Equal(expected[size-2]))
+				}
+			})
+
+			It("shares structure with appended versions", func() {
+				first := vector.Append(-1)
+				second := vector.Append(-2)
+				Expect(first.Get(size)).To(Equal(-1))
+				Expect(second.Get(size)).To(Equal(-2))
+				Expect(vector.Size()).To(Equal(size))
+			})
+		})
+	}
+
+	When("using a transient", func() {
+		var transient *immut.TransientVector[int]
+
+		BeforeEach(func() {
+			vector = immut.VectorFromSlice([]int{1, 2, 3})
+			transient = vector.Transient()
+		})
+
+		It("is possible to apply batch changes", func() {
+			for i := range 100 {
+				transient.Append(i)
+			}
+			transient.Set(0, -1)
+			transient.Set(50, -50)
+			transient.Pop()
+			Expect(transient.Size()).To(Equal(102))
+			Expect(transient.Get(0)).To(Equal(-1))
+
+			result := transient.Persistent()
+			Expect(result.Size()).To(Equal(102))
+			Expect(result.Get(0)).To(Equal(-1))
+			Expect(result.Get(50)).To(Equal(-50))
+			Expect(result.Get(101)).To(Equal(98))
+		})
+
+		It("does not affect the original", func() {
+			transient.Set(0, -1)
+			transient.Pop()
+			for i := range 100 {
+				transient.Append(i)
+			}
+			Expect(vector.Items()).To(Equal([]int{1, 2, 3}))
+		})
+
+		It("does not affect versions derived from the result", func() {
+			for i := range 40 {
+				transient.Append(i)
+			}
+			result := transient.Persistent()
+			derived := result.Set(1, -1).Append(-2)
+
+			again := result.Transient()
+			again.Set(1, 100)
+			again.Set(42, 100)
+			again.Append(100)
+			Expect(result.Get(1)).To(Equal(2))
+			Expect(result.Get(42)).To(Equal(39))
+			Expect(derived.Get(1)).To(Equal(-1))
+			Expect(derived.Size()).To(Equal(44))
+		})
+
+		It("panics when used after becoming persistent", func() {
+			transient.Persistent()
+			Expect(func() { transient.Append(1) }).To(Panic())
+			Expect(func() { transient.Persistent() }).To(Panic())
+		})
+	})
+
+	It("is possible to construct from a sequence", func() {
+		vector = immut.VectorFromSeq(seq.Range(5, 9))
+		Expect(vector.Items()).To(Equal([]int{5, 6, 7, 8, 9}))
+	})
+})
@@ -0,0 +1,98 @@
+// Package binheap provides the binary heap operations that are shared
+// between the ds and seq packages, which cannot depend on each other.
+//
+// The functions operate on a plain slice, where the item at index i has
+// its children at indices 2i+1 and 2i+2. When better returns true, the
+// first argument is placed higher in the heap.
+package binheap
+
+// MovedFunc is notified whenever an item is placed at a new index.
+type MovedFunc[T any] func(value T, index int)
+
+// Init arranges the specified items into a heap in O(n) time.
+func Init[T any](items []T, better func(a, b T) bool, moved MovedFunc[T]) {
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		SiftDown(items, i, better, moved)
+	}
+}
+
+// Fix restores the heap order after the item at the specified index has
+// been replaced or has changed.
+func Fix[T any](items []T, index int, better func(a, b T) bool, moved MovedFunc[T]) {
+	if index > 0 && better(items[index], items[(index-1)/2]) {
+		SiftUp(items, index, better, moved)
+	} else {
+		SiftDown(items, index, better, moved)
+	}
+}
+
+// SiftUp moves the item at the specified index towards the root, until its
+// parent is at least as good as it.
+func SiftUp[T any](items []T, index int, better func(a, b T) bool, moved MovedFunc[T]) {
+	value := items[index]
+	for index > 0 {
+		parentIndex := (index - 1) / 2
+		parentValue := items[parentIndex]
+		if !better(value, parentValue) {
+			return
+		}
+		place(items, index, parentValue, moved)
+		place(items, parentIndex, value, moved)
+		index = parentIndex
+	}
+}
+
+// SiftDown moves the item at the specified index away from the root, until
+// none of its children is better than it.
+func SiftDown[T any](items []T, index int, better func(a, b T) bool, moved MovedFunc[T]) {
+	value := items[index]
+	leftChildIndex := index*2 + 1
+	for leftChildIndex < len(items) {
+		bestIndex := index
+
+		leftValue := items[leftChildIndex]
+		if better(leftValue, value) {
+			bestIndex = leftChildIndex
+		}
+
+		rightChildIndex := leftChildIndex + 1
+		if rightChildIndex < len(items) {
+			rightValue := items[rightChildIndex]
+			if better(rightValue, value) && better(rightValue, leftValue) {
+				bestIndex = rightChildIndex
+			}
+		}
+
+		if bestIndex == index {
+			return
+		}
+
+		place(items, index, items[bestIndex], moved)
+		place(items, bestIndex, value, moved)
+
+		index = bestIndex
+		leftChildIndex = index*2 + 1
+	}
+}
+
+// Compare adapts the specified better function to a comparison function
+// that can be used with slices.SortFunc, where better items come first.
+func Compare[T any](better func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case better(a, b):
+			return -1
+		case better(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+func place[T any](items []T, index int, value T, moved MovedFunc[T]) {
+	items[index] = value
+	if moved != nil {
+		moved(value, index)
+	}
+}
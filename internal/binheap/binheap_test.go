@@ -0,0 +1,76 @@
+package binheap_test
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mokiat/gog/internal/binheap"
+)
+
+var _ = Describe("Binary Heap", func() {
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	isHeap := func(items []int) bool {
+		for i := 1; i < len(items); i++ {
+			if less(items[i], items[(i-1)/2]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	It("builds a heap from unordered items", func() {
+		items := []int{9, 4, 7, 1, 8, 2, 6, 3, 5}
+		binheap.Init(items, less, nil)
+		Expect(isHeap(items)).To(BeTrue())
+		Expect(items[0]).To(Equal(1))
+	})
+
+	It("sifts an appended item up", func() {
+		items := []int{2, 4, 3}
+		items = append(items, 1)
+		binheap.SiftUp(items, len(items)-1, less, nil)
+		Expect(isHeap(items)).To(BeTrue())
+		Expect(items[0]).To(Equal(1))
+	})
+
+	It("sifts a replaced root down", func() {
+		items := []int{1, 3, 2, 5, 4}
+		items[0] = 6
+		binheap.SiftDown(items, 0, less, nil)
+		Expect(isHeap(items)).To(BeTrue())
+		Expect(items[0]).To(Equal(2))
+	})
+
+	It("fixes items that changed in either direction", func() {
+		items := []int{1, 3, 2, 5, 4, 7, 6}
+		items[5] = 0
+		binheap.Fix(items, 5, less, nil)
+		Expect(isHeap(items)).To(BeTrue())
+		items[0] = 10
+		binheap.Fix(items, 0, less, nil)
+		Expect(isHeap(items)).To(BeTrue())
+	})
+
+	It("reports moved items", func() {
+		items := []int{5, 4, 3, 2, 1}
+		indices := make(map[int]int)
+		binheap.Init(items, less, func(value, index int) {
+			indices[value] = index
+		})
+		for value, index := range indices {
+			Expect(items[index]).To(Equal(value))
+		}
+		Expect(indices).To(HaveKeyWithValue(1, 0))
+	})
+
+	It("adapts a better function for sorting", func() {
+		items := []int{3, 1, 2, 1}
+		slices.SortFunc(items, binheap.Compare(less))
+		Expect(items).To(Equal([]int{1, 1, 2, 3}))
+	})
+})
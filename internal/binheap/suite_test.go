@@ -0,0 +1,13 @@
+package binheap_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBinheap(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Binary Heap Suite")
+}
@@ -3,6 +3,8 @@ package seq
 import (
 	"iter"
 	"slices"
+
+	"github.com/mokiat/gog/internal/binheap"
 )
 
 // CollectCap collects values from src into a new slice with the given capacity
@@ -12,3 +14,31 @@ func CollectCap[T any](src iter.Seq[T], cap int) []T {
 	result = slices.AppendSeq(result, src)
 	return result
 }
+
+// TopK returns the best k values from src, sorted from best to worst,
+// according to the specified better function. The source sequence is
+// traversed once and at most k values are kept in memory at any time.
+func TopK[T any](src iter.Seq[T], k int, better func(a, b T) bool) []T {
+	if k <= 0 {
+		return nil
+	}
+	// The kept values form a heap where the worst one is at the root, so
+	// that it can be replaced when a better value arrives.
+	worse := func(a, b T) bool {
+		return better(b, a)
+	}
+	var kept []T
+	for value := range src {
+		if len(kept) < k {
+			kept = append(kept, value)
+			binheap.SiftUp(kept, len(kept)-1, worse, nil)
+			continue
+		}
+		if better(value, kept[0]) {
+			kept[0] = value
+			binheap.SiftDown(kept, 0, worse, nil)
+		}
+	}
+	slices.SortFunc(kept, binheap.Compare(better))
+	return kept
+}
@@ -1,6 +1,7 @@
 package seq_test
 
 import (
+	"math"
 	"slices"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -22,4 +23,32 @@ var _ = Describe("Collect", func() {
 
 	})
 
+	Describe("TopK", func() {
+
+		It("returns the best values in sorted order", func() {
+			iter := slices.Values([]int{4, 17, 1, 8, 23, 8, 2, 15})
+			result := seq.TopK(iter, 3, func(a, b int) bool {
+				return a > b
+			})
+			Expect(result).To(Equal([]int{23, 17, 15}))
+		})
+
+		It("supports a very large k", func() {
+			iter := slices.Values([]int{3, 1, 2})
+			result := seq.TopK(iter, math.MaxInt, func(a, b int) bool {
+				return a > b
+			})
+			Expect(result).To(Equal([]int{3, 2, 1}))
+		})
+
+		It("returns all values when there are fewer than k", func() {
+			iter := slices.Values([]int{3, 1, 2})
+			result := seq.TopK(iter, 5, func(a, b int) bool {
+				return a < b
+			})
+			Expect(result).To(Equal([]int{1, 2, 3}))
+		})
+
+	})
+
 })
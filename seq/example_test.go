@@ -116,3 +116,14 @@ func ExampleSum() {
 	// Output:
 	// 6
 }
+
+func ExampleTopK() {
+	source := slices.Values([]string{"pear", "fig", "banana", "kiwi", "apple"})
+	target := seq.TopK(source, 2, func(a, b string) bool {
+		return len(a) > len(b)
+	})
+	fmt.Println(target)
+
+	// Output:
+	// [banana apple]
+}
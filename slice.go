@@ -5,6 +5,7 @@ import (
 	"slices"
 
 	"github.com/mokiat/gog/constr"
+	"github.com/mokiat/gog/ds"
 )
 
 // Map can be used to transform one slice into another by providing a
@@ -80,6 +81,24 @@ func Mapping[S any, K comparable, V any](slice []S, fn func(S) (K, V)) map[K][]V
 	return result
 }
 
+// PartitionInto is similar to Partition, except that it stores the groups in
+// the specified MultiMap, which is then returned.
+func PartitionInto[S comparable, K comparable](target *ds.MultiMap[K, S], slice []S, fn func(S) K) *ds.MultiMap[K, S] {
+	for _, v := range slice {
+		target.Put(fn(v), v)
+	}
+	return target
+}
+
+// MappingInto is similar to Mapping, except that it stores the buckets in
+// the specified MultiMap, which is then returned.
+func MappingInto[S any, K comparable, V comparable](target *ds.MultiMap[K, V], slice []S, fn func(S) (K, V)) *ds.MultiMap[K, V] {
+	for _, v := range slice {
+		target.Put(fn(v))
+	}
+	return target
+}
+
 // Dedupe returns a new slice that contains only distinct elements from
 // the original slice.
 func Dedupe[T comparable](slice []T) []T {
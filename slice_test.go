@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/mokiat/gog"
+	"github.com/mokiat/gog/ds"
 )
 
 var _ = Describe("Slice", func() {
@@ -107,6 +108,36 @@ var _ = Describe("Slice", func() {
 		})
 	})
 
+	Describe("PartitionInto", func() {
+		It("partitions a slice into a multimap", func() {
+			source := []int{0, 1, 2, 3, 4, 5, 6}
+			target := gog.PartitionInto(ds.NewMultiMap[string, int](0), source, func(v int) string {
+				if v%2 == 0 {
+					return "even"
+				} else {
+					return "odd"
+				}
+			})
+			Expect(target.Get("even")).To(Equal([]int{0, 2, 4, 6}))
+			Expect(target.Get("odd")).To(Equal([]int{1, 3, 5}))
+		})
+	})
+
+	Describe("MappingInto", func() {
+		It("partitions a slice into custom multimap buckets", func() {
+			source := []int{0, 1, 2, 3, 4, 5, 6}
+			target := gog.MappingInto(ds.NewSetMultiMap[string, string](0), source, func(v int) (string, string) {
+				if v%2 == 0 {
+					return "even", strconv.Itoa(v % 4)
+				} else {
+					return "odd", strconv.Itoa(v % 4)
+				}
+			})
+			Expect(target.Get("even")).To(Equal([]string{"0", "2"}))
+			Expect(target.Get("odd")).To(Equal([]string{"1", "3"}))
+		})
+	})
+
 	Describe("Dedupe", func() {
 		It("returns a slice of distinct elements", func() {
 			source := []int{0, 0, 2, 3, 3, 5, 6, 6}